@@ -0,0 +1,270 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package argon2_crypt implements the Argon2 password hashing algorithm, winner
+// of the Password Hashing Competition, in the PHC string format of the
+// reference implementation:
+//
+//	$argon2id$v=19$m=65536,t=3,p=4$c29tZXNhbHQ$hash
+//
+// The three variants, Argon2id, Argon2i and Argon2d, are supported, as well as
+// hashes of version 0x10 which have no "v=" field. The salt and the hash are
+// encoded in standard base64 without padding; hashes of any length are
+// verified.
+//
+// The specification for this algorithm can be found here:
+// https://www.rfc-editor.org/rfc/rfc9106
+package argon2_crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"strconv"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/internal/argon2"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.ARGON2, New,
+		MagicPrefixID, MagicPrefixI, MagicPrefixD)
+}
+
+const (
+	MagicPrefix   = MagicPrefixID
+	MagicPrefixID = "$argon2id$"
+	MagicPrefixI  = "$argon2i$"
+	MagicPrefixD  = "$argon2d$"
+
+	SaltLenMin = 8  // in bytes
+	SaltLenMax = 16 // in bytes, for generated salts only
+	HashLenMin = 4
+	HashLen    = 32
+
+	// MemoryMax bounds the memory size of the hashed keys which are verified,
+	// as the whole memory is allocated at once: 1 GiB, as for scrypt.
+	MemoryMax = internal.ScryptMemoryMax / 1024 // in KiB
+
+	// TimeMax and ThreadsMax bound the number of passes and of lanes, each
+	// of which runs in its own goroutine. ThreadsMax is the largest degree
+	// of parallelism of golang.org/x/crypto/argon2.
+	TimeMax    = 1024
+	ThreadsMax = 255
+)
+
+// DefaultParams are the parameters used when no salt is given: 64 MiB of
+// memory, three passes and four lanes, as recommended by RFC 9106 for
+// memory-constrained environments.
+var DefaultParams = Params{Memory: 64 * 1024, Time: 3, Threads: 4}
+
+var ErrParamsUnknown = errors.New("argon2: unsupported parameters")
+
+// Params are the Argon2 cost parameters encoded in a hashed key.
+type Params struct {
+	Memory  uint32 // in KiB, at least 8 times Threads and at most MemoryMax
+	Time    uint32 // number of passes, at most TimeMax
+	Threads uint32 // degree of parallelism, or number of lanes, at most ThreadsMax
+}
+
+func (p Params) valid() bool {
+	return p.Time >= 1 && p.Time <= TimeMax && p.Threads >= 1 && p.Threads <= ThreadsMax &&
+		uint64(p.Memory) >= 8*uint64(p.Threads) && p.Memory <= MemoryMax
+}
+
+var variants = map[string]int{
+	MagicPrefixID: argon2.Argon2id,
+	MagicPrefixI:  argon2.Argon2i,
+	MagicPrefixD:  argon2.Argon2d,
+}
+
+var b64 = base64.RawStdEncoding.Strict()
+
+// GenerateSalt returns a setting made of the given magic prefix, which selects
+// the variant, the current version, the given parameters and a random salt of
+// SaltLenMax bytes.
+func GenerateSalt(magicPrefix string, params Params) ([]byte, error) {
+	if _, ok := variants[magicPrefix]; !ok {
+		return nil, common.ErrSaltPrefix
+	}
+	if !params.valid() {
+		return nil, ErrParamsUnknown
+	}
+
+	salt := make([]byte, SaltLenMax)
+	rand.Read(salt)
+
+	buf := bytes.Buffer{}
+	buf.WriteString(magicPrefix)
+	writeParams(&buf, argon2.Version13, params)
+	buf.WriteByte('$')
+	buf.WriteString(b64.EncodeToString(salt))
+	return buf.Bytes(), nil
+}
+
+func writeParams(buf *bytes.Buffer, version int, params Params) {
+	if version != argon2.Version10 {
+		buf.WriteString("v=")
+		buf.WriteString(strconv.Itoa(version))
+		buf.WriteByte('$')
+	}
+	buf.WriteString("m=")
+	buf.WriteString(strconv.FormatUint(uint64(params.Memory), 10))
+	buf.WriteString(",t=")
+	buf.WriteString(strconv.FormatUint(uint64(params.Time), 10))
+	buf.WriteString(",p=")
+	buf.WriteString(strconv.FormatUint(uint64(params.Threads), 10))
+}
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the Argon2 password hashing. When
+// no salt is given, it generates Argon2id hashes with DefaultParams.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix: []byte(MagicPrefix),
+			SaltLenMin:  SaltLenMin,
+			SaltLenMax:  SaltLenMax,
+		},
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = GenerateSalt(string(c.Salt.MagicPrefix), DefaultParams); err != nil {
+			return "", err
+		}
+	}
+	h, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	hashLen := HashLen
+	if h.hashLen != 0 {
+		hashLen = h.hashLen
+	}
+	sum := argon2.Key(h.variant, h.version, key, h.salt,
+		h.params.Time, h.params.Memory, h.params.Threads, uint32(hashLen))
+
+	buf := bytes.Buffer{}
+	buf.Write(h.setting)
+	buf.WriteByte('$')
+	buf.WriteString(b64.EncodeToString(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the time cost, that is the number of passes over the memory.
+// The memory size and the parallelism are not taken into account.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	h, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return int(h.params.Time), nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+type decoded struct {
+	setting []byte // reproduced in the output
+	variant int
+	version int
+	params  Params
+	salt    []byte
+	hashLen int // zero if raw is a bare setting
+}
+
+// decode parses raw, which is either a setting or a hashed key. Only the
+// canonical encoding of the parameters, as produced by the reference
+// implementation, is accepted.
+func (c *crypter) decode(raw []byte) (h decoded, err error) {
+	if len(raw) == 0 {
+		return h, common.ErrSaltPrefix
+	}
+	i := bytes.IndexByte(raw[1:], '$') + 2
+	if i < 2 {
+		return h, common.ErrSaltPrefix
+	}
+	var ok bool
+	if h.variant, ok = variants[string(raw[:i])]; !ok {
+		return h, common.ErrSaltPrefix
+	}
+
+	fields := bytes.Split(raw[i:], []byte("$"))
+	h.version = argon2.Version10
+	if len(fields) > 0 && bytes.HasPrefix(fields[0], []byte("v=")) {
+		v, ok := parseUint32(fields[0][2:])
+		if !ok || v != argon2.Version13 && v != argon2.Version10 {
+			return h, ErrParamsUnknown
+		}
+		h.version = int(v)
+		fields = fields[1:]
+	}
+	if len(fields) < 2 || len(fields) > 3 {
+		return h, common.ErrSaltFormat
+	}
+
+	params := bytes.Split(fields[0], []byte(","))
+	if len(params) != 3 {
+		return h, ErrParamsUnknown
+	}
+	for j, p := range []*uint32{&h.params.Memory, &h.params.Time, &h.params.Threads} {
+		name := []byte{"mtp"[j], '='}
+		if !bytes.HasPrefix(params[j], name) {
+			return h, ErrParamsUnknown
+		}
+		if *p, ok = parseUint32(params[j][2:]); !ok {
+			return h, common.ErrSaltRounds
+		}
+	}
+	if !h.params.valid() {
+		return h, common.ErrSaltRounds
+	}
+
+	if h.salt, err = b64.DecodeString(string(fields[1])); err != nil || len(h.salt) < c.Salt.SaltLenMin {
+		return h, common.ErrSaltFormat
+	}
+
+	if len(fields) == 3 {
+		sum, err := b64.DecodeString(string(fields[2]))
+		if err != nil || len(sum) < HashLenMin {
+			return h, common.ErrSaltFormat
+		}
+		h.hashLen = len(sum)
+		h.setting = raw[:len(raw)-len(fields[2])-1]
+	} else {
+		h.setting = raw
+	}
+	return h, nil
+}
+
+// parseUint32 parses a decimal number without leading zeros.
+func parseUint32(src []byte) (uint32, bool) {
+	if len(src) > 1 && src[0] == '0' {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(string(src), 10, 32)
+	if err != nil {
+		return 0, false
+	}
+	return uint32(v), true
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package argon2_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+var argon2Crypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("$argon2i$v=19$m=65536,t=2,p=1$c29tZXNhbHQ"),
+			[]byte("password"),
+			"$argon2i$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$wWKIMhR9lyDFvRz9YTZweHKfbftvj+qf+YFY4NeBbtA",
+			2,
+		},
+		{
+			[]byte("$argon2i$m=65536,t=2,p=1$c29tZXNhbHQ"),
+			[]byte("password"),
+			"$argon2i$m=65536,t=2,p=1$c29tZXNhbHQ$9sTbSlTio3Biev89thdrlKKiCaYsjjYVJxGAL3swxpQ",
+			2,
+		},
+		{
+			[]byte("$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ"),
+			[]byte("password"),
+			"$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			2,
+		},
+		{
+			[]byte("$argon2d$v=19$m=256,t=3,p=2$c29tZXNhbHQ"),
+			[]byte("password"),
+			"$argon2d$v=19$m=256,t=3,p=2$c29tZXNhbHQ$YlmgeG+A/ocNsNnxalWenhJKDYoc+mRxTGlP7qW/4EA",
+			3,
+		},
+		{
+			// The length of the hash is kept.
+			[]byte("$argon2id$v=19$m=4096,t=3,p=4$c2FsdHNhbHRzYWx0c2FsdA$AAAAAAAAAAAAAAAAAAAAAA"),
+			[]byte("Hello world!"),
+			"$argon2id$v=19$m=4096,t=3,p=4$c2FsdHNhbHRzYWx0c2FsdA$ArnwlYdnvtRJL7CbhFUPJw",
+			3,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := argon2Crypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := argon2Crypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"$argon2x$v=19$m=65536,t=2,p=1$c29tZXNhbHQ",
+		"$argon2id$v=18$m=65536,t=2,p=1$c29tZXNhbHQ",
+		"$argon2id$v=19$t=2,m=65536,p=1$c29tZXNhbHQ",
+		"$argon2id$v=19$m=065536,t=2,p=1$c29tZXNhbHQ",
+		"$argon2id$v=19$m=65536,t=0,p=1$c29tZXNhbHQ",
+		"$argon2id$v=19$m=15,t=2,p=2$c29tZXNhbHQ",
+		"$argon2id$v=19$m=65536,t=2,p=1,data=AAAA$c29tZXNhbHQ",
+		"$argon2id$v=19$m=65536,t=2,p=1$c2FsdA",
+		"$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ=",
+		"$argon2id$v=19$m=65536,t=2,p=1",
+	}
+	for i, d := range data {
+		if _, err := argon2Crypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestParamsMax(t *testing.T) {
+	data := []string{
+		"$argon2id$v=19$m=4294967295,t=1,p=1$c2FsdHNhbHQ$AAAAAAAAAAAAAAAAAAAAAA",
+		"$argon2id$v=19$m=1048577,t=1,p=1$c2FsdHNhbHQ$AAAAAAAAAAAAAAAAAAAAAA",
+		"$argon2id$v=19$m=65536,t=1025,p=1$c2FsdHNhbHQ$AAAAAAAAAAAAAAAAAAAAAA",
+		"$argon2id$v=19$m=65536,t=1,p=256$c2FsdHNhbHQ$AAAAAAAAAAAAAAAAAAAAAA",
+		"$argon2id$v=19$m=1048576,t=1,p=16777215$c2FsdHNhbHQ$AAAAAAAAAAAAAAAAAAAAAA",
+	}
+	for i, d := range data {
+		if err := argon2Crypt.Verify(d, []byte("password")); err != common.ErrSaltRounds {
+			t.Errorf("Test %d failed\nExpected: %v, got: %v", i, common.ErrSaltRounds, err)
+		}
+	}
+
+	for _, p := range []Params{
+		{Memory: MemoryMax + 1, Time: 1, Threads: 1},
+		{Memory: 1024, Time: TimeMax + 1, Threads: 1},
+		{Memory: 4096, Time: 1, Threads: ThreadsMax + 1},
+	} {
+		if _, err := GenerateSalt(MagicPrefixID, p); err == nil {
+			t.Errorf("Parameters %v were accepted", p)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	salt, err := GenerateSalt(MagicPrefixI, Params{Memory: 1024, Time: 4, Threads: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(salt), "$argon2i$v=19$m=1024,t=4,p=2$") || len(salt) != 29+22 {
+		t.Errorf("Unexpected salt %s", salt)
+	}
+
+	hash, err := argon2Crypt.Generate([]byte("password"), salt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = argon2Crypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verification failed: %s", hash)
+	}
+
+	if _, err = GenerateSalt("$argon2$", DefaultParams); err == nil {
+		t.Errorf("Unknown prefix was accepted")
+	}
+	if _, err = GenerateSalt(MagicPrefixID, Params{Memory: 8, Time: 1, Threads: 2}); err == nil {
+		t.Errorf("Invalid parameters were accepted")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	data := [][]byte{
+		[]byte("password"),
+		[]byte("12345"),
+		[]byte("That's amazing! I've got the same combination on my luggage!"),
+		[]byte("And change the combination on my luggage!"),
+		[]byte("         random  spa  c    ing."),
+		[]byte("94ajflkvjzpe8u3&*j1k513KLJ&*()"),
+	}
+	for i, d := range data {
+		hash, err := argon2Crypt.Generate(d, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(hash, "$argon2id$v=19$m=65536,t=3,p=4$") {
+			t.Errorf("Test %d failed: unexpected prefix %s", i, hash)
+		}
+		if err = argon2Crypt.Verify(hash, d); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err = argon2Crypt.Verify(hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+}
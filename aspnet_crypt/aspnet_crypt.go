@@ -0,0 +1,200 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package aspnet_crypt implements the PasswordHasher of ASP.NET Identity. A
+// hashed key is a standard base64 blob which starts with a format byte:
+//
+//	version 2: 0x00, salt (16 bytes), subkey (32 bytes)
+//	version 3: 0x01, PRF, iteration count, salt length, salt, subkey
+//
+// Version 2 is PBKDF2-HMAC-SHA1 with 1000 iterations. In version 3, the PRF,
+// the iteration count and the salt length are 32-bit big-endian integers, and
+// the PRF is 0 for HMAC-SHA1, 1 for HMAC-SHA256 and 2 for HMAC-SHA512.
+//
+// As hashed keys have no prefix, they are recognized by their content. A
+// setting is a blob without the subkey.
+package aspnet_crypt
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/binary"
+	"hash"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+func init() {
+	crypt.RegisterCryptMatcher(crypt.ASPNET, New, isHash)
+}
+
+const (
+	FormatV2 = 0x00
+	FormatV3 = 0x01
+
+	PRFHMACSHA1   = 0
+	PRFHMACSHA256 = 1
+	PRFHMACSHA512 = 2
+
+	SaltLenMin    = 16 // in bytes
+	SaltLenMax    = 16 // in bytes, for generated salts only
+	HashLenMin    = 16
+	HashLen       = 32
+	RoundsMin     = 1
+	RoundsMax     = 1<<31 - 1
+	RoundsDefault = 100000 // with HMAC-SHA512, as .NET 7 and later generate
+	RoundsV2      = 1000
+)
+
+const headerLenV3 = 1 + 4 + 4 + 4
+
+var prfs = []func() hash.Hash{
+	PRFHMACSHA1:   sha1.New,
+	PRFHMACSHA256: sha256.New,
+	PRFHMACSHA512: sha512.New,
+}
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the ASP.NET Identity password
+// hashing. When no salt is given, it generates version 3 hashes with
+// HMAC-SHA512 and RoundsDefault iterations.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+// GenerateSalt returns a version 3 setting with the given PRF and number of
+// iterations, and a random salt of SaltLenMax bytes.
+func GenerateSalt(prf, rounds int) ([]byte, error) {
+	if prf < 0 || prf >= len(prfs) {
+		return nil, common.ErrSaltFormat
+	}
+	if rounds < RoundsMin || rounds > RoundsMax {
+		return nil, common.ErrSaltRounds
+	}
+
+	blob := make([]byte, headerLenV3+SaltLenMax)
+	blob[0] = FormatV3
+	binary.BigEndian.PutUint32(blob[1:], uint32(prf))
+	binary.BigEndian.PutUint32(blob[5:], uint32(rounds))
+	binary.BigEndian.PutUint32(blob[9:], SaltLenMax)
+	rand.Read(blob[headerLenV3:])
+
+	return []byte(pbkdf2.Base64.EncodeToString(blob)), nil
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = GenerateSalt(PRFHMACSHA512, c.Salt.RoundsDefault); err != nil {
+			return "", err
+		}
+	}
+	h, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	hashLen := HashLen
+	if len(h.sum) != 0 {
+		hashLen = len(h.sum)
+	}
+	sum := pbkdf2.Key(key, h.salt, h.rounds, hashLen, prfs[h.prf])
+
+	blob := append(h.setting[:len(h.setting):len(h.setting)], sum...)
+	return pbkdf2.Base64.EncodeToString(blob), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the number of iterations.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	h, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return h.rounds, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+type decoded struct {
+	setting []byte // the blob without the subkey
+	prf     int
+	rounds  int
+	salt    []byte
+	sum     []byte // the subkey, if any
+}
+
+func (c *crypter) decode(raw []byte) (h decoded, err error) {
+	blob, err := pbkdf2.Base64.DecodeString(string(raw))
+	if err != nil || len(blob) == 0 {
+		return h, common.ErrSaltFormat
+	}
+
+	switch blob[0] {
+	case FormatV2:
+		if len(blob) != 1+SaltLenMax && len(blob) != 1+SaltLenMax+HashLen {
+			return h, common.ErrSaltFormat
+		}
+		h.prf, h.rounds = PRFHMACSHA1, RoundsV2
+		h.setting, h.salt = blob[:1+SaltLenMax], blob[1:1+SaltLenMax]
+
+	case FormatV3:
+		if len(blob) < headerLenV3 {
+			return h, common.ErrSaltFormat
+		}
+		prf := binary.BigEndian.Uint32(blob[1:])
+		rounds := binary.BigEndian.Uint32(blob[5:])
+		saltLen := binary.BigEndian.Uint32(blob[9:])
+		if prf >= uint32(len(prfs)) {
+			return h, common.ErrSaltFormat
+		}
+		if rounds < uint32(c.Salt.RoundsMin) || rounds > uint32(c.Salt.RoundsMax) {
+			return h, common.ErrSaltRounds
+		}
+		if saltLen < uint32(c.Salt.SaltLenMin) || saltLen > uint32(len(blob)-headerLenV3) {
+			return h, common.ErrSaltFormat
+		}
+		h.prf, h.rounds = int(prf), int(rounds)
+		end := headerLenV3 + int(saltLen)
+		h.setting, h.salt = blob[:end], blob[headerLenV3:end]
+
+	default:
+		return h, common.ErrSaltPrefix
+	}
+
+	if h.sum = blob[len(h.setting):]; len(h.sum) != 0 && len(h.sum) < HashLenMin {
+		return h, common.ErrSaltFormat
+	}
+	return h, nil
+}
+
+// isHash reports whether hashedKey is a complete hashed key of version 2 or 3.
+func isHash(hashedKey string) bool {
+	h, err := New().(*crypter).decode([]byte(hashedKey))
+	return err == nil && len(h.sum) != 0
+}
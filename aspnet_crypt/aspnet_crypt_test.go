@@ -0,0 +1,155 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package aspnet_crypt
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var aspnetCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("AAABAgMEBQYHCAkKCwwNDg8DCeL+Tgvf59D+SCjUHCNEFuLZv7Yc3Y9kOhHPv9/BGQ=="),
+			[]byte("password"),
+			"AAABAgMEBQYHCAkKCwwNDg8DCeL+Tgvf59D+SCjUHCNEFuLZv7Yc3Y9kOhHPv9/BGQ==",
+			RoundsV2,
+		},
+		{
+			[]byte("AQAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg8="),
+			[]byte("password"),
+			"AQAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg/rbIFTVZIgPAkrFY+NOQlnI2Km9dvQDZgoBEy6qLJS6Q==",
+			10000,
+		},
+		{
+			[]byte("AQAAAAIAAYagAAAAEAABAgMEBQYHCAkKCwwNDg/73hTTOMxvghBX8/SnisILxwGxHjepOzeQw1EOAZRz8w=="),
+			[]byte("password"),
+			"AQAAAAIAAYagAAAAEAABAgMEBQYHCAkKCwwNDg/73hTTOMxvghBX8/SnisILxwGxHjepOzeQw1EOAZRz8w==",
+			100000,
+		},
+		{
+			[]byte("AQAAAAAAAAPoAAAAEAABAgMEBQYHCAkKCwwNDg8DCeL+Tgvf59D+SCjUHCNEFuLZv7Yc3Y9kOhHPv9/BGQ=="),
+			[]byte("password"),
+			"AQAAAAAAAAPoAAAAEAABAgMEBQYHCAkKCwwNDg8DCeL+Tgvf59D+SCjUHCNEFuLZv7Yc3Y9kOhHPv9/BGQ==",
+			1000,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := aspnetCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := aspnetCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"AgAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg8=", // format 2
+		"AQAAAAMAACcQAAAAEAABAgMEBQYHCAkKCwwNDg8=", // PRF 3
+		"AQAAAAEAAAAAAAAAEAABAgMEBQYHCAkKCwwNDg8=", // no iterations
+		"AQAAAAEAACcQAAAACAABAgMEBQYH",             // 8-byte salt
+		"AQAAAAEAACcQAAAAEQABAgMEBQYHCAkKCwwNDg8=", // truncated salt
+		"AQAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg8BAgM=",
+		"AAABAgMEBQYHCAkKCwwNDg8D",
+		"AQAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg8",
+	}
+	for i, d := range data {
+		if _, err := aspnetCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	salt, err := GenerateSalt(PRFHMACSHA256, 10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	blob, err := base64.StdEncoding.DecodeString(string(salt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(blob) != headerLenV3+SaltLenMax || string(blob[:headerLenV3]) != "\x01\x00\x00\x00\x01\x00\x00\x27\x10\x00\x00\x00\x10" {
+		t.Errorf("Unexpected salt %x", blob)
+	}
+
+	if _, err = GenerateSalt(3, 10000); err == nil {
+		t.Errorf("PRF 3 was accepted")
+	}
+	if _, err = GenerateSalt(PRFHMACSHA256, 0); err == nil {
+		t.Errorf("Rounds 0 were accepted")
+	}
+}
+
+func TestIsHash(t *testing.T) {
+	data := []struct {
+		hash string
+		ok   bool
+	}{
+		{"AAABAgMEBQYHCAkKCwwNDg8DCeL+Tgvf59D+SCjUHCNEFuLZv7Yc3Y9kOhHPv9/BGQ==", true},
+		{"AQAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg/rbIFTVZIgPAkrFY+NOQlnI2Km9dvQDZgoBEy6qLJS6Q==", true},
+		{"AQAAAAEAACcQAAAAEAABAgMEBQYHCAkKCwwNDg8=", false},
+		{"abJnggxhB/yWI", false},
+		{"$1$salt$hash", false},
+	}
+	for i, d := range data {
+		if isHash(d.hash) != d.ok {
+			t.Errorf("Test %d failed: %s", i, d.hash)
+		}
+	}
+	if !crypt.IsHashSupported(data[1].hash) {
+		t.Errorf("%s is not supported", data[1].hash)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys in the formats of ASP.NET Identity version 2 and 3,
+	// computed with Python's hashlib.
+	data := []string{
+		"AKGyw9Tl9gcYKTpLXG1+j5C5HQ6mS+WvgsQxAKu1jPU81r+ddWmr7lwZitIDlkbphA==",
+		"AQAAAAEAACcQAAAAEKGyw9Tl9gcYKTpLXG1+j5ArJbrLu93OiZsmmBnztO6Ck9cjBpZrrpB1zweswnBA6A==",
+		"AQAAAAIAAYagAAAAEKGyw9Tl9gcYKTpLXG1+j5Dg6pcLZWenKk8CpYPHNpXo9YFAE34U7MAGYV9fRlqASA==",
+	}
+	for i, d := range data {
+		if err := aspnetCrypt.Verify(d, []byte("secret")); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err := aspnetCrypt.Verify(d, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	hash, err := aspnetCrypt.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isHash(hash) {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = aspnetCrypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verification failed: %s", hash)
+	}
+}
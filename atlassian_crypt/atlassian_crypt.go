@@ -0,0 +1,110 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package atlassian_crypt implements the {PKCS5S2} password hashing of Jira,
+// Confluence and the other Atlassian products, which is PBKDF2-HMAC-SHA1 with
+// 10000 iterations. The salt and the derived key are encoded together in
+// standard base64:
+//
+//	{PKCS5S2}NzIyNzM0NzY3NTIwNjI3MdDDis7wPxSbSzfFqDGf7u/L00kSEnupbz36XCL0m7wa
+//
+// A setting is either a hashed key, or the magic prefix followed by the
+// base64-encoded salt.
+package atlassian_crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.ATLASSIAN, New, MagicPrefix)
+}
+
+const (
+	MagicPrefix = "{PKCS5S2}"
+	SaltLenMin  = 16 // in bytes
+	SaltLenMax  = 16
+	Rounds      = 10000
+	HashLen     = 32
+)
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the Atlassian PBKDF2 password
+// hashing.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix: []byte(MagicPrefix),
+			SaltLenMin:  SaltLenMin,
+			SaltLenMax:  SaltLenMax,
+		},
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	rawSalt, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	sum := pbkdf2.SHA1(key, rawSalt, Rounds, HashLen)
+
+	buf := bytes.Buffer{}
+	buf.Write(c.Salt.MagicPrefix)
+	buf.WriteString(pbkdf2.Base64.EncodeToString(append(rawSalt, sum...)))
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the fixed number of iterations.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	if _, err := c.decode([]byte(hashedKey)); err != nil {
+		return 0, err
+	}
+	return Rounds, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decode returns the salt of raw, or a random salt if raw is empty.
+func (c *crypter) decode(raw []byte) ([]byte, error) {
+	if len(raw) == 0 {
+		salt := make([]byte, c.Salt.SaltLenMax)
+		rand.Read(salt)
+		return salt, nil
+	}
+	if !bytes.HasPrefix(raw, c.Salt.MagicPrefix) {
+		return nil, common.ErrSaltPrefix
+	}
+
+	salt, err := pbkdf2.Base64.DecodeString(string(raw[len(c.Salt.MagicPrefix):]))
+	if err != nil {
+		return nil, common.ErrSaltFormat
+	}
+	if len(salt) == c.Salt.SaltLenMax+HashLen {
+		// A hashed key, whose salt is followed by the derived key.
+		salt = salt[:c.Salt.SaltLenMax]
+	}
+	if len(salt) < c.Salt.SaltLenMin || len(salt) > c.Salt.SaltLenMax {
+		return nil, common.ErrSaltFormat
+	}
+	return salt, nil
+}
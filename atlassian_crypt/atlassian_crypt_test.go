@@ -0,0 +1,98 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package atlassian_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var atlassianCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+	}{
+		{
+			[]byte("{PKCS5S2}NzIyNzM0NzY3NTIwNjI3MdDDis7wPxSbSzfFqDGf7u/L00kSEnupbz36XCL0m7wa"),
+			[]byte("hashcat"),
+			"{PKCS5S2}NzIyNzM0NzY3NTIwNjI3MdDDis7wPxSbSzfFqDGf7u/L00kSEnupbz36XCL0m7wa",
+		},
+		{
+			[]byte("{PKCS5S2}AAECAwQFBgcICQoLDA0ODw=="),
+			[]byte("password"),
+			"{PKCS5S2}AAECAwQFBgcICQoLDA0OD44+L3PD62OQqBq7yBAcA0OwF6ev//tatl4TTwkJ3Mos",
+		},
+	}
+
+	for i, d := range data {
+		hash, err := atlassianCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := atlassianCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != Rounds {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, Rounds, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"{PKCS5S1}AAECAwQFBgcICQoLDA0ODw==",
+		"{PKCS5S2}AAECAwQFBgcICQoLDA0O",
+		"{PKCS5S2}AAECAwQFBgcICQoLDA0ODw",
+		"{PKCS5S2}AAECAwQFBgcICQoLDA0ODxAR",
+		"{PKCS5S2}",
+	}
+	for i, d := range data {
+		if _, err := atlassianCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of the hashcat examples, and of Atlassian's
+	// DefaultPasswordEncoder computed with Python's hashlib.
+	data := []struct {
+		hash string
+		key  []byte
+	}{
+		{"{PKCS5S2}NzIyNzM0NzY3NTIwNjI3MdDDis7wPxSbSzfFqDGf7u/L00kSEnupbz36XCL0m7wa", []byte("hashcat")},
+		{"{PKCS5S2}obLD1OX2BxgpOktcbX6PkAFITLfm2hVFkmDCCAKiB75H5BOLKGNddj1F2wEzAY4Z", []byte("secret")},
+		{"{PKCS5S2}obLD1OX2BxgpOktcbX6PkFctCCucql5EenEcVTgC/6qwHoqvQM7CGwqWmzlt03iM", []byte("correct horse battery staple")},
+	}
+	for i, d := range data {
+		if err := atlassianCrypt.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := atlassianCrypt.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	hash, err := atlassianCrypt.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, MagicPrefix) || len(hash) != len(MagicPrefix)+64 {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = atlassianCrypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verification failed: %s", hash)
+	}
+}
@@ -0,0 +1,235 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package bcrypt_crypt implements the bcrypt password hashing algorithm
+// designed by Niels Provos and David Mazières for OpenBSD.
+//
+// All the variants handled by Solar Designer's crypt_blowfish are supported:
+// "$2a$", "$2b$" and "$2y$", and "$2x$" which reproduces the sign extension
+// bug of crypt_blowfish versions before 1.1 for keys with 8-bit characters.
+//
+// The specification for this algorithm can be found here:
+// https://www.usenix.org/legacy/events/usenix99/provos/provos.pdf
+package bcrypt_crypt
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"strconv"
+
+	"golang.org/x/crypto/blowfish"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.BCRYPT, New,
+		MagicPrefix2a, MagicPrefix2b, MagicPrefix2x, MagicPrefix2y)
+}
+
+const (
+	MagicPrefix   = MagicPrefix2b
+	MagicPrefix2a = "$2a$"
+	MagicPrefix2b = "$2b$"
+	MagicPrefix2x = "$2x$"
+	MagicPrefix2y = "$2y$"
+
+	SaltLenMin    = 22
+	SaltLenMax    = 22
+	RoundsMin     = 4
+	RoundsMax     = 31
+	RoundsDefault = 10
+)
+
+const (
+	keyLen      = 72 // 18 words of the P-array
+	saltBytes   = 16
+	checksumLen = 31
+)
+
+var magicCipherData = []byte("OrpheanBeholderScryDoubt")
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the bcrypt password hashing.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsDefault: RoundsDefault,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+		},
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		salt = c.Salt.GenerateWCost(c.Salt.RoundsDefault)
+	}
+	prefix, cost, salt, err := decodeSalt(salt)
+	if err != nil {
+		return "", err
+	}
+	rawSalt, err := common.DecodeBase64_Bcrypt(salt)
+	if err != nil || len(rawSalt) != saltBytes {
+		return "", common.ErrSaltFormat
+	}
+
+	expanded, initial := setKey(key, prefix[2])
+	cipher, err := blowfish.NewSaltedCipher(initial, rawSalt)
+	if err != nil {
+		return "", err
+	}
+	for i := uint64(0); i < 1<<uint(cost); i++ {
+		blowfish.ExpandKey(expanded, cipher)
+		blowfish.ExpandKey(rawSalt, cipher)
+	}
+	internal.CleanSensitiveData(expanded)
+	internal.CleanSensitiveData(initial)
+
+	sum := make([]byte, len(magicCipherData))
+	copy(sum, magicCipherData)
+	for i := 0; i < len(sum); i += 8 {
+		for j := 0; j < 64; j++ {
+			cipher.Encrypt(sum[i:i+8], sum[i:i+8])
+		}
+	}
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(prefix) + 3 + SaltLenMax + checksumLen)
+	buf.Write(prefix)
+	if cost < 10 {
+		buf.WriteByte('0')
+	}
+	buf.WriteString(strconv.Itoa(cost))
+	buf.WriteByte('$')
+	// Re-encoding the salt clears the unused low bits of its last character,
+	// as crypt_blowfish does.
+	buf.Write(common.Base64_Bcrypt(rawSalt))
+	// Only 23 of the 24 bytes are encoded, following the original
+	// implementation.
+	buf.Write(common.Base64_Bcrypt(sum[:23]))
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the base-2 logarithm of the number of rounds, as written in the
+// hashed key.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	_, cost, _, err := decodeSalt([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return cost, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decodeSalt splits a salt of the form "$2b$10$<22 characters>", which may be
+// followed by a checksum, into the magic prefix, the cost and the encoded salt.
+func decodeSalt(raw []byte) (prefix []byte, cost int, salt []byte, err error) {
+	if len(raw) < len(MagicPrefix)+3+SaltLenMax {
+		err = common.ErrSaltFormat
+		return
+	}
+
+	prefix = raw[:len(MagicPrefix)]
+	switch string(prefix) {
+	case MagicPrefix2a, MagicPrefix2b, MagicPrefix2x, MagicPrefix2y:
+	default:
+		err = common.ErrSaltPrefix
+		return
+	}
+
+	rest := raw[len(prefix):]
+	if rest[2] != '$' || rest[0] < '0' || rest[0] > '9' || rest[1] < '0' || rest[1] > '9' {
+		err = common.ErrSaltFormat
+		return
+	}
+	cost = int(rest[0]-'0')*10 + int(rest[1]-'0')
+	if cost < RoundsMin || cost > RoundsMax {
+		err = common.ErrSaltRounds
+		return
+	}
+
+	salt = rest[3 : 3+SaltLenMax]
+	return
+}
+
+// setKey turns key into the two 72-byte sequences of P-array words used by
+// crypt_blowfish: expanded is XORed into the P-array at every round, and
+// initial is used for the first, salted, key schedule.
+//
+// The key is read as a NUL-terminated string which is repeated as needed. For
+// the "$2x$" variant, every byte is sign-extended before being added to the
+// current word. For the "$2a$" variant, keys that would be affected by that bug
+// get a distinct initial state, so that their hashes never collide with the
+// buggy ones.
+func setKey(key []byte, minor byte) (expanded, initial []byte) {
+	bug := minor == 'x'
+	safety := minor == 'a'
+
+	if i := bytes.IndexByte(key, 0); i >= 0 {
+		key = key[:i]
+	}
+
+	expanded = make([]byte, keyLen)
+	initial = make([]byte, keyLen)
+
+	var sign, diff uint32
+	ptr := 0
+	for i := 0; i < keyLen; i += 4 {
+		var correct, buggy uint32
+		for j := 0; j < 4; j++ {
+			var b byte
+			if ptr < len(key) {
+				b = key[ptr]
+				ptr++
+			} else {
+				ptr = 0
+			}
+
+			correct = correct<<8 | uint32(b)
+			buggy = buggy<<8 | uint32(int32(int8(b)))
+			if j > 0 {
+				sign |= buggy & 0x80
+			}
+		}
+		diff |= correct ^ buggy
+
+		word := correct
+		if bug {
+			word = buggy
+		}
+		putWord(expanded[i:], word)
+		putWord(initial[i:], word)
+	}
+
+	if safety && sign != 0 && diff == 0 {
+		initial[1] ^= 0x01 // bit 16 of the first word
+	}
+	return
+}
+
+func putWord(b []byte, w uint32) {
+	b[0] = byte(w >> 24)
+	b[1] = byte(w >> 16)
+	b[2] = byte(w >> 8)
+	b[3] = byte(w)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package bcrypt_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+var bcryptCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("$2a$05$CCCCCCCCCCCCCCCCCCCCC."),
+			[]byte("U*U"),
+			"$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW",
+			5,
+		},
+		{
+			[]byte("$2a$05$CCCCCCCCCCCCCCCCCCCCC/"),
+			[]byte("U*U"),
+			"$2a$05$CCCCCCCCCCCCCCCCCCCCC.E5YPO9kmyuRGyh0XouQYb4YMJKvyOeW",
+			5,
+		},
+		{
+			[]byte("$2b$06$DCq7YPn5Rq63x1Lad4cll."),
+			[]byte(""),
+			"$2b$06$DCq7YPn5Rq63x1Lad4cll.TV4S6ytwfsfvkgY8jIucDrjc8deX1s.",
+			6,
+		},
+		{
+			[]byte("$2b$04$abcdefghijklmnopqrstuu"),
+			[]byte("Hello world!"),
+			"$2b$04$abcdefghijklmnopqrstuuyeG8laUfZvsCmc.AE6qIDYSPGM2efmK",
+			4,
+		},
+		{
+			[]byte("$2y$10$abcdefghijklmnopqrstuu"),
+			[]byte("password"),
+			"$2y$10$abcdefghijklmnopqrstuu5Lo0g67CiD3M4RpN1BmBb4Crp5w7dbK",
+			10,
+		},
+		{
+			[]byte("$2b$05$abcdefghijklmnopqrstuu"),
+			[]byte("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ" +
+				"0123456789chars after 72 are ignored"),
+			"$2b$05$abcdefghijklmnopqrstuu5s2v8.iXieOjg/.AySBTTZIIVFJeBui",
+			5,
+		},
+		{
+			[]byte("$2a$05$/OK.fbVrR/bpIqNJ5ianF."),
+			[]byte("\xa3"),
+			"$2a$05$/OK.fbVrR/bpIqNJ5ianF.Sa7shbm4.OzKpvFnX1pQLmQW96oUlCq",
+			5,
+		},
+		{
+			[]byte("$2x$05$/OK.fbVrR/bpIqNJ5ianF."),
+			[]byte("\xa3"),
+			"$2x$05$/OK.fbVrR/bpIqNJ5ianF.CE5elHaaO4EbggVDjb8P19RukzXSM3e",
+			5,
+		},
+		{
+			[]byte("$2a$05$/OK.fbVrR/bpIqNJ5ianF."),
+			[]byte("\xff\xff\xa3"),
+			"$2a$05$/OK.fbVrR/bpIqNJ5ianF.nqd1wy.pTMdcvrRWxyiGL2eMz.2a85.",
+			5,
+		},
+		{
+			[]byte("$2b$05$/OK.fbVrR/bpIqNJ5ianF."),
+			[]byte("\xff\xff\xa3"),
+			"$2b$05$/OK.fbVrR/bpIqNJ5ianF.CE5elHaaO4EbggVDjb8P19RukzXSM3e",
+			5,
+		},
+		{
+			[]byte("$2x$05$/OK.fbVrR/bpIqNJ5ianF."),
+			[]byte("\xff\xff\xa3"),
+			"$2x$05$/OK.fbVrR/bpIqNJ5ianF.CE5elHaaO4EbggVDjb8P19RukzXSM3e",
+			5,
+		},
+		{
+			[]byte("$2y$05$/OK.fbVrR/bpIqNJ5ianF."),
+			[]byte("\xff\xff\xa3"),
+			"$2y$05$/OK.fbVrR/bpIqNJ5ianF.CE5elHaaO4EbggVDjb8P19RukzXSM3e",
+			5,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := bcryptCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := bcryptCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"$2c$05$CCCCCCCCCCCCCCCCCCCCC.",
+		"$2b$5$CCCCCCCCCCCCCCCCCCCCC.",
+		"$2b$03$CCCCCCCCCCCCCCCCCCCCC.",
+		"$2b$32$CCCCCCCCCCCCCCCCCCCCC.",
+		"$2b$05$CCCCCCCCCCCC",
+		"$2b$05$CCCCCCCCCCCCCCCCCCCC*.",
+	}
+	for i, d := range data {
+		if _, err := bcryptCrypt.Generate([]byte("U*U"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestSetSalt(t *testing.T) {
+	c := New()
+	c.SetSalt(common.Salt{
+		MagicPrefix:   []byte(MagicPrefix),
+		SaltLenMin:    SaltLenMin,
+		SaltLenMax:    SaltLenMax,
+		RoundsMin:     RoundsMin,
+		RoundsMax:     RoundsMax,
+		RoundsDefault: 5,
+	})
+	hash, err := c.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$2b$05$") {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	data := [][]byte{
+		[]byte("password"),
+		[]byte("12345"),
+		[]byte("That's amazing! I've got the same combination on my luggage!"),
+		[]byte("And change the combination on my luggage!"),
+		[]byte("         random  spa  c    ing."),
+		[]byte("94ajflkvjzpe8u3&*j1k513KLJ&*()"),
+	}
+	for i, d := range data {
+		hash, err := bcryptCrypt.Generate(d, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(hash, "$2b$10$") {
+			t.Errorf("Test %d failed: unexpected prefix %s", i, hash)
+		}
+		if err = bcryptCrypt.Verify(hash, d); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err = bcryptCrypt.Verify(hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package bsdi_crypt implements the extended DES-based crypt(3) function of
+// BSD/OS, also found on FreeBSD and NetBSD.
+//
+// The setting is made of the magic prefix, a count of rounds and a salt of 24
+// bits each, encoded in four characters:
+//
+//	_J9..salt
+//
+// Unlike the traditional DES-based crypt, every character of the key is
+// significant. Only the 7 low bits of each of them are used though.
+package bsdi_crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/internal/des"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.BSDI, New, MagicPrefix)
+}
+
+const (
+	MagicPrefix   = "_"
+	SaltLenMin    = 4
+	SaltLenMax    = 4
+	RoundsMin     = 1
+	RoundsMax     = 1<<24 - 1
+	RoundsDefault = 725 // "J9..", as libxcrypt generates
+)
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the BSDi extended DES-based crypt.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+// GenerateSalt returns a setting with the given count of rounds and a random
+// salt. Even counts should be avoided as they reveal weak keys.
+func GenerateSalt(rounds int) ([]byte, error) {
+	return generateSalt([]byte(MagicPrefix), rounds)
+}
+
+func generateSalt(magicPrefix []byte, rounds int) ([]byte, error) {
+	if rounds < RoundsMin || rounds > RoundsMax {
+		return nil, common.ErrSaltRounds
+	}
+
+	salt := make([]byte, 3)
+	rand.Read(salt)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(magicPrefix) + 4 + SaltLenMax)
+	buf.Write(magicPrefix)
+	buf.Write(common.Base64_24BitUint(uint32(rounds), 4))
+	buf.Write(common.Base64_24Bit(salt))
+	return buf.Bytes(), nil
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = generateSalt(c.Salt.MagicPrefix, c.Salt.RoundsDefault); err != nil {
+			return "", err
+		}
+	}
+	setting, rounds, saltBits, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+	if rounds == 0 {
+		// libxcrypt runs a single round in that case.
+		rounds = 1
+	}
+
+	// As in crypt(3), the key ends at the first NUL byte.
+	if i := bytes.IndexByte(key, 0); i >= 0 {
+		key = key[:i]
+	}
+
+	keyBuf := make([]byte, 8)
+	for i := 0; i < len(key) && i < len(keyBuf); i++ {
+		keyBuf[i] = key[i] << 1
+	}
+	cipher := des.NewCipher(keyBuf)
+	// Each further group of 8 characters is folded into the key, which is
+	// encrypted with itself first.
+	for i := len(keyBuf); i < len(key); i += len(keyBuf) {
+		cipher.Encrypt(keyBuf, keyBuf, 0, 1)
+		for j := 0; j < len(keyBuf) && i+j < len(key); j++ {
+			keyBuf[j] ^= key[i+j] << 1
+		}
+		cipher.SetKey(keyBuf)
+	}
+	internal.CleanSensitiveData(keyBuf)
+
+	sum := make([]byte, 8)
+	cipher.Encrypt(sum, sum, saltBits, rounds)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(setting) + 11)
+	buf.Write(setting)
+	buf.Write(des.EncodeBlock(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the count of rounds encoded in the hashed key.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	_, rounds, _, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return rounds, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decode splits raw into the setting part which is reproduced in the output,
+// the count of rounds and the salt.
+func (c *crypter) decode(raw []byte) (setting []byte, rounds int, salt uint32, err error) {
+	if !bytes.HasPrefix(raw, c.Salt.MagicPrefix) {
+		err = common.ErrSaltPrefix
+		return
+	}
+	rest := raw[len(c.Salt.MagicPrefix):]
+	if len(rest) < 4+c.Salt.SaltLenMin {
+		err = common.ErrSaltFormat
+		return
+	}
+
+	count, err := common.DecodeBase64_24BitUint(rest[:4])
+	if err != nil {
+		err = common.ErrSaltRounds
+		return
+	}
+	if salt, err = common.DecodeBase64_24BitUint(rest[4 : 4+c.Salt.SaltLenMin]); err != nil {
+		err = common.ErrSaltFormat
+		return
+	}
+
+	setting = raw[:len(c.Salt.MagicPrefix)+4+c.Salt.SaltLenMin]
+	return setting, int(count), salt, nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package bsdi_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var bsdiCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("_J9..CCCC"),
+			[]byte("U*U"),
+			"_J9..CCCC2hjwL0cmP4.",
+			725,
+		},
+		{
+			[]byte("_zzz.abcd"),
+			[]byte("U*U"),
+			"_zzz.abcdq/ysf1PXAEg",
+			262143,
+		},
+		{
+			[]byte("_J9..SDiz"),
+			[]byte("a very long password exceeding 8"),
+			"_J9..SDizakXweJ9fejU",
+			725,
+		},
+		{
+			[]byte("_J9..SDizUsxsXqYns0c"),
+			[]byte(""),
+			"_J9..SDizUsxsXqYns0c",
+			725,
+		},
+		{
+			[]byte("_Gl/.SDiz"),
+			[]byte("12345678"),
+			"_Gl/.SDizswHlT8o4TfI",
+			7250,
+		},
+		{
+			// A count of zero is handled as a single round.
+			[]byte("_....abcd"),
+			[]byte("hello"),
+			"_....abcdJoSnM3MEX3Q",
+			0,
+		},
+		{
+			[]byte("_/...abcd"),
+			[]byte("hello"),
+			"_/...abcdJoSnM3MEX3Q",
+			1,
+		},
+		{
+			[]byte("_J9..CCCC"),
+			[]byte("U*U\x00ignored"),
+			"_J9..CCCC2hjwL0cmP4.",
+			725,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := bsdiCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := bsdiCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	for i, d := range []string{"$J9..CCCC", "_J9..CC!C", "_J*..CCCC", "_J9..CCC"} {
+		if _, err := bsdiCrypt.Generate([]byte("U*U"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	salt, err := GenerateSalt(7250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(salt), "_Gl/.") || len(salt) != 9 {
+		t.Errorf("Unexpected salt %s", salt)
+	}
+
+	for _, rounds := range []int{0, 1 << 24} {
+		if _, err = GenerateSalt(rounds); err == nil {
+			t.Errorf("Rounds %d were accepted", rounds)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of libxcrypt and John the Ripper.
+	data := []struct {
+		hash string
+		key  []byte
+	}{
+		{"_J9..CCCCXBrJUJV154M", []byte("U*U*U*U*")},
+		{"_J9..CCCCXUhOBTXzaiE", []byte("U*U***U")},
+		{"_J9..SDizUsxsXqYns0c", []byte("")},
+		{"_Gl/.SDiz3o6COSQDlaw", []byte("multiple words")},
+		{"_QZ..abcdvP8rMJf1HMk", []byte("0.s0.P0.Z0.n0.")},
+	}
+	for i, d := range data {
+		if err := bsdiCrypt.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := bsdiCrypt.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	hash, err := bsdiCrypt.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "_J9..") || len(hash) != 20 {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = bsdiCrypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verification failed: %s", hash)
+	}
+}
@@ -0,0 +1,197 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package cisco implements the password hashing of Cisco IOS and NX-OS.
+//
+// Type 8 and type 9 hashes are made of the magic prefix, a salt of 14
+// characters, and the checksum encoded in base64 with Cisco's alphabet:
+//
+//	$8$TnGX/fE4KGHOVU$pEhnEvxrvaynpi8j4f.EMHr6M.FzU8xnZnBr/tJdFWk
+//	$9$2MJBozw/9R3UsU$2lFhcKvpghcyw8deP25GOfyZaagyUOGBymkryvOdfo6
+//
+// Type 8 is PBKDF2-HMAC-SHA256 with 20000 iterations, and type 9 is scrypt
+// with N=16384, r=1 and p=1. Neither of them can be tuned.
+//
+// Type 5 hashes are MD5-crypt hashes with a salt of 4 characters.
+package cisco
+
+import (
+	"bytes"
+	"crypto/subtle"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/md5_crypt"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.CISCO8, NewType8, MagicPrefixType8)
+	crypt.RegisterCrypt(crypt.CISCO9, NewType9, MagicPrefixType9)
+}
+
+const (
+	MagicPrefixType8 = "$8$"
+	MagicPrefixType9 = "$9$"
+	SaltLen          = 14
+	SaltLenType5     = 4
+	Type8Rounds      = 20000
+	Type9N           = 16384
+	Type9R           = 1
+	Type9P           = 1
+	HashLen          = 32
+)
+
+type crypter struct {
+	Salt common.Salt
+	kdf  func(key, salt []byte) ([]byte, error)
+	cost int
+}
+
+// NewType8 returns a new crypt.Crypter computing the Cisco type 8 password
+// hashing.
+func NewType8() crypt.Crypter {
+	return &crypter{
+		Salt: common.Salt{
+			MagicPrefix: []byte(MagicPrefixType8),
+			SaltLenMin:  SaltLen,
+			SaltLenMax:  SaltLen,
+		},
+		kdf: func(key, salt []byte) ([]byte, error) {
+			return pbkdf2.SHA256(key, salt, Type8Rounds, HashLen), nil
+		},
+		cost: Type8Rounds,
+	}
+}
+
+// NewType9 returns a new crypt.Crypter computing the Cisco type 9 password
+// hashing.
+func NewType9() crypt.Crypter {
+	return &crypter{
+		Salt: common.Salt{
+			MagicPrefix: []byte(MagicPrefixType9),
+			SaltLenMin:  SaltLen,
+			SaltLenMax:  SaltLen,
+		},
+		kdf: func(key, salt []byte) ([]byte, error) {
+			return scrypt.Key(key, salt, Type9N, Type9R, Type9P, HashLen)
+		},
+		cost: 14, // base-2 logarithm of Type9N, as scrypt_crypt reports
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		salt = c.Salt.Generate(c.Salt.SaltLenMax)
+	}
+	salt, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	sum, err := c.kdf(key, salt)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(c.Salt.MagicPrefix) + len(salt) + 1 + pbkdf2.Crypt.EncodedLen(HashLen))
+	buf.Write(c.Salt.MagicPrefix)
+	buf.Write(salt)
+	buf.WriteByte('$')
+	buf.WriteString(pbkdf2.Crypt.EncodeToString(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the number of PBKDF2 iterations for type 8, and the base-2
+// logarithm of N for type 9. Both are fixed.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	if _, err := c.decode([]byte(hashedKey)); err != nil {
+		return 0, err
+	}
+	return c.cost, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decode returns the salt of raw.
+func (c *crypter) decode(raw []byte) ([]byte, error) {
+	if !bytes.HasPrefix(raw, c.Salt.MagicPrefix) {
+		return nil, common.ErrSaltPrefix
+	}
+	salt := raw[len(c.Salt.MagicPrefix):]
+	if i := bytes.IndexByte(salt, '$'); i >= 0 {
+		salt = salt[:i]
+	}
+	if len(salt) < c.Salt.SaltLenMin || len(salt) > c.Salt.SaltLenMax {
+		return nil, common.ErrSaltFormat
+	}
+	for _, ch := range salt {
+		if !isSaltChar(ch) {
+			return nil, common.ErrSaltFormat
+		}
+	}
+	return salt, nil
+}
+
+func isSaltChar(ch byte) bool {
+	return ch == '.' || ch == '/' ||
+		'0' <= ch && ch <= '9' || 'A' <= ch && ch <= 'Z' || 'a' <= ch && ch <= 'z'
+}
+
+type type5Crypter struct{ crypt.Crypter }
+
+// NewType5 returns a new crypt.Crypter computing Cisco type 5 hashes, that is
+// MD5-crypt hashes whose salt has exactly SaltLenType5 characters. Salts of
+// another length are rejected rather than truncated.
+func NewType5() crypt.Crypter {
+	c := md5_crypt.New()
+	c.SetSalt(common.Salt{
+		MagicPrefix:   []byte(md5_crypt.MagicPrefix),
+		SaltLenMin:    SaltLenType5,
+		SaltLenMax:    SaltLenType5,
+		RoundsDefault: md5_crypt.RoundsDefault,
+	})
+	return type5Crypter{c}
+}
+
+func (c type5Crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) != 0 {
+		if !bytes.HasPrefix(salt, []byte(md5_crypt.MagicPrefix)) {
+			return "", common.ErrSaltPrefix
+		}
+		rest := salt[len(md5_crypt.MagicPrefix):]
+		if i := bytes.IndexByte(rest, '$'); i >= 0 {
+			rest = rest[:i]
+		}
+		if len(rest) != SaltLenType5 {
+			return "", common.ErrSaltFormat
+		}
+	}
+	return c.Crypter.Generate(key, salt)
+}
+
+func (c type5Crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package cisco
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var (
+	type5Crypt = NewType5()
+	type8Crypt = NewType8()
+	type9Crypt = NewType9()
+)
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		crypter crypt.Crypter
+		salt    []byte
+		key     []byte
+		out     string
+		cost    int
+	}{
+		{
+			type8Crypt,
+			[]byte("$8$TnGX/fE4KGHOVU$pEhnEvxrvaynpi8j4f.EMHr6M.FzU8xnZnBr/tJdFWk"),
+			[]byte("hashcat"),
+			"$8$TnGX/fE4KGHOVU$pEhnEvxrvaynpi8j4f.EMHr6M.FzU8xnZnBr/tJdFWk",
+			Type8Rounds,
+		},
+		{
+			type8Crypt,
+			[]byte("$8$saltsaltsaltsa"),
+			[]byte("password"),
+			"$8$saltsaltsaltsa$HQtj3dM7vYk60ed5TNlWdbe0WRmn2eM3kuyB9tdiqWQ",
+			Type8Rounds,
+		},
+		{
+			type9Crypt,
+			[]byte("$9$2MJBozw/9R3UsU$2lFhcKvpghcyw8deP25GOfyZaagyUOGBymkryvOdfo6"),
+			[]byte("hashcat"),
+			"$9$2MJBozw/9R3UsU$2lFhcKvpghcyw8deP25GOfyZaagyUOGBymkryvOdfo6",
+			14,
+		},
+		{
+			type9Crypt,
+			[]byte("$9$saltsaltsaltsa$"),
+			[]byte("password"),
+			"$9$saltsaltsaltsa$CDM1TqQ24bcR68slEfjAzJAI9E5Gg0lu7hMNovjqvR2",
+			14,
+		},
+		{
+			type5Crypt,
+			[]byte("$1$mERr$"),
+			[]byte("cisco"),
+			"$1$mERr$hx5rVt7rPNoS4wqbXKX7m0",
+			1000,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := d.crypter.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := d.crypter.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []struct {
+		crypter crypt.Crypter
+		salt    string
+	}{
+		{type8Crypt, "$9$saltsaltsaltsa$"},
+		{type8Crypt, "$8$saltsalt$"},
+		{type8Crypt, "$8$saltsaltsaltsalt$"},
+		{type8Crypt, "$8$saltsalt:altsa$"},
+		{type9Crypt, "$8$saltsaltsaltsa$"},
+		{type9Crypt, "$9$$"},
+		{type5Crypt, "$1$salt1$"},
+		{type5Crypt, "$1$sal$"},
+		{type5Crypt, "$5$salt$"},
+	}
+	for i, d := range data {
+		if _, err := d.crypter.Generate([]byte("password"), []byte(d.salt)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d.salt)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of Cisco IOS and of the hashcat examples.
+	data := []struct {
+		crypter crypt.Crypter
+		hash    string
+		key     []byte
+	}{
+		{type5Crypt, "$1$mERr$hx5rVt7rPNoS4wqbXKX7m0", []byte("cisco")},
+		{type8Crypt, "$8$dsYGNam3K1SIJO$7nv/35M/qr6t.dVc7UY9zrJDWRVqncHub1PE9UlMQFs", []byte("cisco")},
+		{type8Crypt, "$8$TnGX/fE4KGHOVU$pEhnEvxrvaynpi8j4f.EMHr6M.FzU8xnZnBr/tJdFWk", []byte("hashcat")},
+		{type9Crypt, "$9$nhEmQVczB7dqsO$X.HsgL6x1il0RxkOSSvyQYwucySCt7qFm4v7pqCxkKM", []byte("cisco")},
+		{type9Crypt, "$9$2MJBozw/9R3UsU$2lFhcKvpghcyw8deP25GOfyZaagyUOGBymkryvOdfo6", []byte("hashcat")},
+	}
+	for i, d := range data {
+		if err := d.crypter.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := d.crypter.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	crypters := []struct {
+		crypter crypt.Crypter
+		prefix  string
+		saltLen int
+	}{
+		{type5Crypt, "$1$", SaltLenType5},
+		{type8Crypt, MagicPrefixType8, SaltLen},
+		{type9Crypt, MagicPrefixType9, SaltLen},
+	}
+	for i, c := range crypters {
+		hash, err := c.crypter.Generate([]byte("password"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(hash, c.prefix) || strings.IndexByte(hash[len(c.prefix):], '$') != c.saltLen {
+			t.Errorf("Test %d failed: unexpected hash %s", i, hash)
+		}
+		if err = c.crypter.Verify(hash, []byte("password")); err != nil {
+			t.Errorf("Test %d failed: %s", i, hash)
+		}
+	}
+}
@@ -4,6 +4,12 @@
 
 package common
 
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
 const (
 	alphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 )
@@ -57,3 +63,101 @@ func Base64_24Bit(src []byte) []byte {
 	}
 	return dst
 }
+
+// ErrBase64Format is returned by the decoders when the encoded string is
+// malformed.
+var ErrBase64Format = errors.New("invalid base64 encoding")
+
+// DecodeBase64_24Bit decodes the output of Base64_24Bit.
+//
+// As the encoding is canonical, the unused high bits of a trailing partial
+// group must be zero.
+func DecodeBase64_24Bit(src []byte) ([]byte, error) {
+	if len(src)%4 == 1 {
+		return nil, ErrBase64Format
+	}
+
+	dst := make([]byte, 0, len(src)*6/8)
+	for si := 0; si < len(src); si += 4 {
+		n := len(src) - si
+		if n > 4 {
+			n = 4
+		}
+
+		var val uint
+		for i := 0; i < n; i++ {
+			c := strings.IndexByte(alphabet, src[si+i])
+			if c < 0 {
+				return nil, ErrBase64Format
+			}
+			val |= uint(c) << (6 * uint(i))
+		}
+
+		for bits := n * 6; bits >= 8; bits -= 8 {
+			dst = append(dst, byte(val))
+			val >>= 8
+		}
+		if val != 0 {
+			return nil, ErrBase64Format
+		}
+	}
+	return dst, nil
+}
+
+// Base64_24BitUint encodes the low 6*n bits of v in n characters of the
+// Base64_24Bit alphabet, least significant first. The DES-based crypt
+// functions encode their salt and their count of rounds this way.
+func Base64_24BitUint(v uint32, n int) []byte {
+	dst := make([]byte, n)
+	for i := range dst {
+		dst[i] = alphabet[v&0x3f]
+		v >>= 6
+	}
+	return dst
+}
+
+// DecodeBase64_24BitUint decodes the output of Base64_24BitUint, which must be
+// at most 5 characters long.
+func DecodeBase64_24BitUint(src []byte) (uint32, error) {
+	if len(src) > 5 {
+		return 0, ErrBase64Format
+	}
+
+	var v uint32
+	for i := len(src) - 1; i >= 0; i-- {
+		c := strings.IndexByte(alphabet, src[i])
+		if c < 0 {
+			return 0, ErrBase64Format
+		}
+		v = v<<6 | uint32(c)
+	}
+	return v, nil
+}
+
+const (
+	bcryptAlphabet = "./ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789"
+)
+
+var bcryptEncoding = base64.NewEncoding(bcryptAlphabet).WithPadding(base64.NoPadding)
+
+// Base64_Bcrypt is the variant of Base64 encoding used by bcrypt to encode its
+// salt and checksum output.
+//
+// Unlike Base64_24Bit, the bytes are taken in big-endian order as Base64 does;
+// only the alphabet differs, and no padding bytes are emitted.
+func Base64_Bcrypt(src []byte) []byte {
+	dst := make([]byte, bcryptEncoding.EncodedLen(len(src)))
+	bcryptEncoding.Encode(dst, src)
+	return dst
+}
+
+// DecodeBase64_Bcrypt decodes the output of Base64_Bcrypt. The unused low bits
+// of the last character are ignored, as bcrypt implementations do.
+func DecodeBase64_Bcrypt(src []byte) ([]byte, error) {
+	dst := make([]byte, bcryptEncoding.DecodedLen(len(src)))
+	n, err := bcryptEncoding.Decode(dst, src)
+	if err != nil {
+		return nil, ErrBase64Format
+	}
+	return dst[:n], nil
+}
@@ -0,0 +1,64 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package common
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDecodeBase64_24Bit(t *testing.T) {
+	for n := 0; n <= 16; n++ {
+		src := make([]byte, n)
+		for i := range src {
+			src[i] = byte(i*37 + n)
+		}
+
+		dst, err := DecodeBase64_24Bit(Base64_24Bit(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(dst, src) {
+			t.Errorf("Expected %x, got %x", src, dst)
+		}
+	}
+
+	for _, s := range []string{"/", "ab", "abcde", "sa*t"} {
+		if _, err := DecodeBase64_24Bit([]byte(s)); err == nil {
+			t.Errorf("Expected \"%s\" to be rejected", s)
+		}
+	}
+}
+
+func TestDecodeBase64_24BitUint(t *testing.T) {
+	data := []struct {
+		src string
+		val uint32
+	}{
+		{"", 0},
+		{"..", 0},
+		{"J9..", 725},
+		{"zzzz", 1<<24 - 1},
+		{"/...", 1},
+	}
+	for i, d := range data {
+		val, err := DecodeBase64_24BitUint([]byte(d.src))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if val != d.val {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.val, val)
+		}
+		if src := Base64_24BitUint(val, len(d.src)); string(src) != d.src {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.src, src)
+		}
+	}
+
+	for _, s := range []string{"sa*t", "......"} {
+		if _, err := DecodeBase64_24BitUint([]byte(s)); err == nil {
+			t.Errorf("Expected \"%s\" to be rejected", s)
+		}
+	}
+}
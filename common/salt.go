@@ -106,6 +106,40 @@ func (s *Salt) GenerateWRounds(length, rounds int) []byte {
 	return out
 }
 
+// GenerateWCost creates a random bcrypt-style salt, which is made of the magic
+// prefix, the two-digit cost parameter and SaltLenMax characters encoded with
+// Base64_Bcrypt.
+//
+// The cost parameter is set thus:
+//
+//   cost < 0: cost = RoundsDefault
+//   cost < RoundsMin: cost = RoundsMin
+//   cost > RoundsMax: cost = RoundsMax
+func (s *Salt) GenerateWCost(cost int) []byte {
+	if cost < 0 {
+		cost = s.RoundsDefault
+	} else if cost < s.RoundsMin {
+		cost = s.RoundsMin
+	} else if cost > s.RoundsMax {
+		cost = s.RoundsMax
+	}
+
+	salt := make([]byte, s.SaltLenMax*6/8)
+	rand.Read(salt)
+
+	costText := strconv.Itoa(cost)
+	if cost < 10 {
+		costText = "0" + costText
+	}
+
+	out := make([]byte, 0, len(s.MagicPrefix)+len(costText)+1+s.SaltLenMax)
+	out = append(out, s.MagicPrefix...)
+	out = append(out, costText...)
+	out = append(out, '$')
+	out = append(out, Base64_Bcrypt(salt)...)
+	return out
+}
+
 func (s *Salt) Decode(raw []byte) (salt []byte, rounds int, isRoundsDef bool, rest []byte, err error) {
 	tokens := bytes.SplitN(raw, []byte{'$'}, 4)
 	if len(tokens) < 3 {
@@ -47,3 +47,33 @@ func TestGenerateSaltWRounds(t *testing.T) {
 		t.Errorf("Expected it has prefix \"%s\", but missing it", expectPrefix)
 	}
 }
+
+func TestGenerateSaltWCost(t *testing.T) {
+	bcryptSalt := &Salt{
+		MagicPrefix:   []byte("$2b$"),
+		SaltLenMin:    22,
+		SaltLenMax:    22,
+		RoundsDefault: 10,
+		RoundsMin:     4,
+		RoundsMax:     31,
+	}
+
+	data := []struct {
+		cost   int
+		prefix string
+	}{
+		{-1, "$2b$10$"},
+		{1, "$2b$04$"},
+		{12, "$2b$12$"},
+		{40, "$2b$31$"},
+	}
+	for _, d := range data {
+		salt := bcryptSalt.GenerateWCost(d.cost)
+		if !strings.HasPrefix(string(salt), d.prefix) {
+			t.Errorf("Expected it has prefix \"%s\", but got \"%s\"", d.prefix, salt)
+		}
+		if len(salt) != len(d.prefix)+22 {
+			t.Errorf("Expected len %d, got len %d", len(d.prefix)+22, len(salt))
+		}
+	}
+}
@@ -8,7 +8,9 @@ package crypt
 
 import (
 	"errors"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/GehirnInc/crypt/common"
 )
@@ -50,21 +52,107 @@ type Crypter interface {
 type Crypt uint
 
 const (
-	APR1   Crypt = 1 + iota // import github.com/GehirnInc/crypt/apr1_crypt
-	MD5                     // import github.com/GehirnInc/crypt/md5_crypt
-	SHA256                  // import github.com/GehirnInc/crypt/sha256_crypt
-	SHA512                  // import github.com/GehirnInc/crypt/sha512_crypt
+	APR1           Crypt = 1 + iota // import github.com/GehirnInc/crypt/apr1_crypt
+	MD5                             // import github.com/GehirnInc/crypt/md5_crypt
+	SHA256                          // import github.com/GehirnInc/crypt/sha256_crypt
+	SHA512                          // import github.com/GehirnInc/crypt/sha512_crypt
+	BCRYPT                          // import github.com/GehirnInc/crypt/bcrypt_crypt
+	YESCRYPT                        // import github.com/GehirnInc/crypt/yescrypt
+	GOST_YESCRYPT                   // import github.com/GehirnInc/crypt/gost_yescrypt
+	SCRYPT                          // import github.com/GehirnInc/crypt/scrypt_crypt
+	ARGON2                          // import github.com/GehirnInc/crypt/argon2_crypt
+	DES                             // import github.com/GehirnInc/crypt/des_crypt
+	BSDI                            // import github.com/GehirnInc/crypt/bsdi_crypt
+	NTHASH                          // import github.com/GehirnInc/crypt/nthash_crypt
+	SUNMD5                          // import github.com/GehirnInc/crypt/sunmd5_crypt
+	SHA1                            // import github.com/GehirnInc/crypt/sha1_crypt
+	CISCO8                          // import github.com/GehirnInc/crypt/cisco
+	CISCO9                          // import github.com/GehirnInc/crypt/cisco
+	LDAP                            // import github.com/GehirnInc/crypt/ldap_crypt
+	PHPASS                          // import github.com/GehirnInc/crypt/phpass_crypt
+	DRUPAL                          // import github.com/GehirnInc/crypt/drupal_crypt
+	DJANGO                          // import github.com/GehirnInc/crypt/django_crypt
+	PASSLIB                         // import github.com/GehirnInc/crypt/passlib_crypt
+	SPRING                          // import github.com/GehirnInc/crypt/spring_crypt
+	WERKZEUG                        // import github.com/GehirnInc/crypt/werkzeug_crypt
+	ATLASSIAN                       // import github.com/GehirnInc/crypt/atlassian_crypt
+	ASPNET                          // import github.com/GehirnInc/crypt/aspnet_crypt
+	MYSQL                           // import github.com/GehirnInc/crypt/dbauth
+	MYSQL_SHA2                      // import github.com/GehirnInc/crypt/dbauth
+	POSTGRES_MD5                    // import github.com/GehirnInc/crypt/dbauth
+	POSTGRES_SCRAM                  // import github.com/GehirnInc/crypt/dbauth
+	ORACLE                          // import github.com/GehirnInc/crypt/dbauth
+	MSSQL                           // import github.com/GehirnInc/crypt/dbauth
+	REDIS                           // import github.com/GehirnInc/crypt/dbauth
+	GRUB                            // import github.com/GehirnInc/crypt/grub_crypt
+	DOVECOT                         // import github.com/GehirnInc/crypt/dovecot_crypt
 	maxCrypt
 )
 
-var crypts = make([]func() Crypter, maxCrypt)
+var cryptNames = [maxCrypt]string{
+	APR1:           "apr1",
+	MD5:            "md5",
+	SHA256:         "sha256",
+	SHA512:         "sha512",
+	BCRYPT:         "bcrypt",
+	YESCRYPT:       "yescrypt",
+	GOST_YESCRYPT:  "gost_yescrypt",
+	SCRYPT:         "scrypt",
+	ARGON2:         "argon2",
+	DES:            "des",
+	BSDI:           "bsdi",
+	NTHASH:         "nthash",
+	SUNMD5:         "sunmd5",
+	SHA1:           "sha1",
+	CISCO8:         "cisco8",
+	CISCO9:         "cisco9",
+	LDAP:           "ldap",
+	PHPASS:         "phpass",
+	DRUPAL:         "drupal",
+	DJANGO:         "django",
+	PASSLIB:        "passlib",
+	SPRING:         "spring",
+	WERKZEUG:       "werkzeug",
+	ATLASSIAN:      "atlassian",
+	ASPNET:         "aspnet",
+	MYSQL:          "mysql",
+	MYSQL_SHA2:     "mysql_sha2",
+	POSTGRES_MD5:   "postgres_md5",
+	POSTGRES_SCRAM: "postgres_scram",
+	ORACLE:         "oracle",
+	MSSQL:          "mssql",
+	REDIS:          "redis",
+	GRUB:           "grub",
+	DOVECOT:        "dovecot",
+}
+
+// String returns the name the Crypt c is registered by, that is the lowercase
+// name of its constant.
+func (c Crypt) String() string {
+	if c > 0 && c < maxCrypt {
+		return cryptNames[c]
+	}
+	return "crypt(" + strconv.FormatUint(uint64(c), 10) + ")"
+}
+
+// registry holds the registered crypt functions by name, along with the
+// prefixes of the hashed keys they handle and the matchers of the Crypt
+// constants.
+var registry = struct {
+	sync.RWMutex
+	factories map[string]func() Crypter
+	prefixes  map[string]string // prefix to name
+	matchers  [maxCrypt]func(hashedKey string) bool
+}{
+	factories: make(map[string]func() Crypter),
+	prefixes:  make(map[string]string),
+}
 
 // New returns new Crypter making the Crypt c.
 // New panics if the Crypt c is unavailable.
 func (c Crypt) New() Crypter {
 	if c > 0 && c < maxCrypt {
-		f := crypts[c]
-		if f != nil {
+		if f := factory(c.String()); f != nil {
 			return f()
 		}
 	}
@@ -73,20 +161,104 @@ func (c Crypt) New() Crypter {
 
 // Available reports whether the Crypt c is available.
 func (c Crypt) Available() bool {
-	return c > 0 && c < maxCrypt && crypts[c] != nil
+	return c > 0 && c < maxCrypt && factory(c.String()) != nil
 }
 
-var cryptPrefixes = make([]string, maxCrypt)
+// Register registers a function that returns a new instance of the crypt
+// function of the given name, along with the prefix of the hashed keys it
+// handles. Unlike RegisterCrypt, it is not limited to the Crypt constants, so
+// that packages outside this module can add their own crypt functions:
+//
+//	crypt.Register("myscheme", "$ms$", myscheme.New)
+//
+// An empty prefix registers the name alone. Register panics if the name is
+// empty or already registered, if f is nil, or if the prefix is already
+// registered. The names of the Crypt constants are reserved for RegisterCrypt,
+// whether their packages are imported or not.
+func Register(name, prefix string, f func() Crypter) {
+	registry.Lock()
+	defer registry.Unlock()
+	if isCryptName(name) {
+		panic("crypt: Register of reserved name " + name)
+	}
+	if registry.factories[name] != nil {
+		panic("crypt: Register called twice for " + name)
+	}
+	register(name, f, prefix)
+}
+
+func isCryptName(name string) bool {
+	for _, n := range cryptNames {
+		if n != "" && n == name {
+			return true
+		}
+	}
+	return false
+}
+
+func register(name string, f func() Crypter, prefixes ...string) {
+	if name == "" || f == nil {
+		panic("crypt: Register of invalid crypt function")
+	}
+	for _, prefix := range prefixes {
+		if other, ok := registry.prefixes[prefix]; ok && other != name {
+			panic("crypt: prefix " + strconv.Quote(prefix) + " is already registered by " + other)
+		}
+	}
+
+	// A name registered again loses the prefixes it was registered with.
+	unregisterPrefixes(name)
+	registry.factories[name] = f
+	for _, prefix := range prefixes {
+		if prefix != "" {
+			registry.prefixes[prefix] = name
+		}
+	}
+}
+
+func unregisterPrefixes(name string) {
+	for prefix, other := range registry.prefixes {
+		if other == name {
+			delete(registry.prefixes, prefix)
+		}
+	}
+}
 
 // RegisterCrypt registers a function that returns a new instance of the given
-// crypt function. This is intended to be called from the init function in
-// packages that implement crypt functions.
-func RegisterCrypt(c Crypt, f func() Crypter, prefix string) {
-	if c >= maxCrypt {
+// crypt function, along with the prefixes of the hashed keys it handles. This
+// is intended to be called from the init function in packages that implement
+// crypt functions, and is the same as Register under the name of c, which it
+// may register again: the prefixes of the previous registration are then
+// replaced by the given ones.
+//
+// A prefix need not start with "$": the "algorithm$" prefixes of Django, or
+// the "{SCHEME}" ones of LDAP, are matched the same way.
+func RegisterCrypt(c Crypt, f func() Crypter, prefixes ...string) {
+	if c == 0 || c >= maxCrypt {
 		panic("crypt: RegisterHash of unknown crypt function")
 	}
-	crypts[c] = f
-	cryptPrefixes[c] = prefix
+	registry.Lock()
+	defer registry.Unlock()
+	register(c.String(), f, prefixes...)
+}
+
+// RegisterCryptMatcher registers a function that returns a new instance of the
+// given crypt function, along with a function reporting whether a hashed key
+// has its shape. It is meant for the crypt functions whose hashed keys have no
+// prefix, and the registered prefixes take precedence. Unlike RegisterCrypt,
+// it leaves the prefixes registered for c in place, so that a crypt function
+// may be found by both.
+func RegisterCryptMatcher(c Crypt, f func() Crypter, match func(hashedKey string) bool) {
+	if c == 0 || c >= maxCrypt {
+		panic("crypt: RegisterCryptMatcher of unknown crypt function")
+	}
+	if f == nil {
+		panic("crypt: Register of invalid crypt function")
+	}
+	registry.Lock()
+	defer registry.Unlock()
+	registry.factories[c.String()] = f
+	registry.matchers[c] = match
 }
 
 // New returns a new crypter.
@@ -94,27 +266,65 @@ func New(c Crypt) Crypter {
 	return c.New()
 }
 
-// IsHashSupported returns true if hashedKey has a supported prefix.
-// NewFromHash will not panic for this hashedKey
-func IsHashSupported(hashedKey string) bool {
-	for i := range cryptPrefixes {
-		prefix := cryptPrefixes[i]
-		if crypts[i] != nil && strings.HasPrefix(hashedKey, prefix) {
-			return true
+// NewByName returns a new Crypter making the crypt function registered by the
+// given name, which is either the String of a Crypt or a name given to
+// Register. NewByName panics if no such crypt function is registered.
+func NewByName(name string) Crypter {
+	if f := factory(name); f != nil {
+		return f()
+	}
+	panic("crypt: requested crypt function is unavailable")
+}
+
+// IsRegistered reports whether a crypt function is registered by the given
+// name.
+func IsRegistered(name string) bool {
+	return factory(name) != nil
+}
+
+func factory(name string) func() Crypter {
+	registry.RLock()
+	defer registry.RUnlock()
+	return registry.factories[name]
+}
+
+// lookup returns the factory of the crypt function with the longest registered
+// prefix of hashedKey, so that "$2b$" takes precedence over "$2", or else of
+// the Crypt whose registered matcher recognizes it.
+func lookup(hashedKey string) (func() Crypter, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	name, n := "", 0
+	for prefix, other := range registry.prefixes {
+		if len(prefix) > n && strings.HasPrefix(hashedKey, prefix) {
+			name, n = other, len(prefix)
 		}
 	}
+	if n > 0 {
+		return registry.factories[name], true
+	}
 
-	return false
+	for i, match := range registry.matchers {
+		if match != nil && match(hashedKey) {
+			return registry.factories[Crypt(uint(i)).String()], true
+		}
+	}
+
+	return nil, false
+}
+
+// IsHashSupported returns true if hashedKey has a supported prefix or shape.
+// NewFromHash will not panic for this hashedKey
+func IsHashSupported(hashedKey string) bool {
+	_, ok := lookup(hashedKey)
+	return ok
 }
 
 // NewFromHash returns a new Crypter using the prefix in the given hashed key.
 func NewFromHash(hashedKey string) Crypter {
-	for i := range cryptPrefixes {
-		prefix := cryptPrefixes[i]
-		if crypts[i] != nil && strings.HasPrefix(hashedKey, prefix) {
-			crypt := Crypt(uint(i))
-			return crypt.New()
-		}
+	if f, ok := lookup(hashedKey); ok {
+		return f()
 	}
 
 	panic("crypt: unknown crypt function")
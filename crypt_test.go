@@ -1,10 +1,15 @@
 package crypt_test
 
 import (
+	"strconv"
+	"sync"
 	"testing"
 
 	"github.com/GehirnInc/crypt"
 	_ "github.com/GehirnInc/crypt/apr1_crypt"
+	_ "github.com/GehirnInc/crypt/bcrypt_crypt"
+	_ "github.com/GehirnInc/crypt/des_crypt"
+	_ "github.com/GehirnInc/crypt/django_crypt"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -14,3 +19,111 @@ func TestIsHashSupported(t *testing.T) {
 	other := crypt.IsHashSupported("$unknown$salt$hash")
 	assert.False(t, other)
 }
+
+func TestIsHashSupportedMultiplePrefixes(t *testing.T) {
+	for _, prefix := range []string{"$2a$", "$2b$", "$2x$", "$2y$"} {
+		assert.True(t, crypt.IsHashSupported(prefix+"10$salt"), prefix)
+	}
+	assert.False(t, crypt.IsHashSupported("$2c$10$salt"))
+}
+
+func TestIsHashSupportedMatcher(t *testing.T) {
+	assert.True(t, crypt.IsHashSupported("abJnggxhB/yWI"))
+	assert.Equal(t, crypt.DES.New(), crypt.NewFromHash("abJnggxhB/yWI"))
+	assert.False(t, crypt.IsHashSupported("abJnggxhB/yW"))
+	assert.False(t, crypt.IsHashSupported("abJnggxhB/y!I"))
+	assert.False(t, crypt.IsHashSupported("plainpassword"))
+}
+
+func TestIsHashSupportedAlgorithmPrefix(t *testing.T) {
+	assert.True(t, crypt.IsHashSupported("pbkdf2_sha256$1000$seasalt$hash"))
+	assert.Equal(t, crypt.DJANGO.New(), crypt.NewFromHash("bcrypt_sha256$$2b$12$salt"))
+	assert.False(t, crypt.IsHashSupported("pbkdf2_sha512$1000$seasalt$hash"))
+}
+
+func TestCryptString(t *testing.T) {
+	assert.Equal(t, "apr1", crypt.APR1.String())
+	assert.Equal(t, "postgres_scram", crypt.POSTGRES_SCRAM.String())
+	assert.Equal(t, "crypt(0)", crypt.Crypt(0).String())
+}
+
+func TestNewByName(t *testing.T) {
+	assert.True(t, crypt.IsRegistered("apr1"))
+	assert.Equal(t, crypt.APR1.New(), crypt.NewByName("apr1"))
+	assert.False(t, crypt.IsRegistered("unknown"))
+	assert.Panics(t, func() { crypt.NewByName("unknown") })
+}
+
+func TestRegister(t *testing.T) {
+	apr1 := func() crypt.Crypter { return crypt.APR1.New() }
+	bcrypt := func() crypt.Crypter { return crypt.BCRYPT.New() }
+	t.Cleanup(func() {
+		crypt.Unregister("test-myscheme")
+		crypt.Unregister("test-myscheme-legacy")
+	})
+
+	assert.False(t, crypt.IsHashSupported("$ms$salt$hash"))
+	crypt.Register("test-myscheme", "$ms$", apr1)
+	crypt.Register("test-myscheme-legacy", "$ms", bcrypt)
+	assert.True(t, crypt.IsRegistered("test-myscheme"))
+
+	// The longest prefix wins regardless of the registration order.
+	assert.Equal(t, crypt.APR1.New(), crypt.NewFromHash("$ms$salt$hash"))
+	assert.Equal(t, crypt.BCRYPT.New(), crypt.NewFromHash("$msv1$salt$hash"))
+	assert.Equal(t, crypt.BCRYPT.New(), crypt.NewFromHash("$2b$10$salt"))
+
+	assert.Panics(t, func() { crypt.Register("test-other", "$ms$", apr1) })
+	assert.Panics(t, func() { crypt.Register("", "$other$", apr1) })
+	assert.Panics(t, func() { crypt.Register("test-other", "$other$", nil) })
+	assert.Panics(t, func() { crypt.Register("test-myscheme", "$ms2$", bcrypt) })
+	assert.Equal(t, crypt.APR1.New(), crypt.NewByName("test-myscheme"))
+
+	// The names of the Crypt constants are reserved, even when unavailable.
+	assert.Panics(t, func() { crypt.Register("apr1", "$apr1x$", bcrypt) })
+	assert.PanicsWithValue(t, "crypt: Register of reserved name sha512", func() { crypt.Register("sha512", "$6x$", bcrypt) })
+	assert.Equal(t, crypt.APR1.New(), crypt.NewByName("apr1"))
+	assert.False(t, crypt.IsRegistered("sha512"))
+	assert.False(t, crypt.IsRegistered("test-other"))
+}
+
+func TestRegisterCryptAgain(t *testing.T) {
+	apr1 := func() crypt.Crypter { return crypt.APR1.New() }
+	t.Cleanup(func() { crypt.Unregister("sha512") })
+
+	crypt.RegisterCrypt(crypt.SHA512, apr1, "$6$", "$6x$")
+	assert.True(t, crypt.IsHashSupported("$6x$salt$hash"))
+
+	// The prefixes of the previous registration are dropped.
+	crypt.RegisterCrypt(crypt.SHA512, apr1, "$6y$")
+	assert.True(t, crypt.IsHashSupported("$6y$salt$hash"))
+	assert.False(t, crypt.IsHashSupported("$6$salt$hash"))
+	assert.False(t, crypt.IsHashSupported("$6x$salt$hash"))
+}
+
+func TestRegisterConcurrent(t *testing.T) {
+	apr1 := func() crypt.Crypter { return crypt.APR1.New() }
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		name := "test-concurrent" + strconv.Itoa(i)
+		t.Cleanup(func() { crypt.Unregister(name) })
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			crypt.Register(name, "$"+name+"$", apr1)
+			assert.True(t, crypt.IsHashSupported("$"+name+"$salt$hash"))
+			assert.True(t, crypt.IsHashSupported("$apr1$salt$hash"))
+			assert.NotNil(t, crypt.NewByName(name))
+		}()
+	}
+	wg.Wait()
+}
+
+func TestUnregister(t *testing.T) {
+	// The tests above leave nothing behind.
+	assert.False(t, crypt.IsRegistered("test-myscheme"))
+	assert.False(t, crypt.IsHashSupported("$ms$salt$hash"))
+	assert.False(t, crypt.IsHashSupported("$test-concurrent0$salt$hash"))
+	assert.False(t, crypt.IsRegistered("sha512"))
+}
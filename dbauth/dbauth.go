@@ -0,0 +1,84 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package dbauth implements the password hashing of database engines, as
+// stored in the mysql.user table of MySQL, the pg_authid catalog of
+// PostgreSQL, the SYS.USER$ table of Oracle Database, the sys.sql_logins view
+// of SQL Server and the ACL of Redis:
+//
+//	*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19
+//	$A$005$saltsaltsaltsaltsalt5SZd752QTW8/mT/5h.Rqvp/3mfPJ3Ut06Xumw96laKC
+//	md532e12f215ba27cb750c9e093ce4b5127
+//	SCRAM-SHA-256$4096:c2FsdHNhbHRzYWx0c2FsdA==$CozjiHjNmiMjBgH9gZ7qn0QWud6nrVP6E72IBh477bQ=:VKers2x8MllK1Rh7LZLqtj6KOTzoFWJpIaokMX3blS0=
+//	S:AC5F1E62D21FD0529428B84D42E8955B0496670338445748184477378130
+//	0x010018102152F8F28C8499D8EF263C53F8BE369D799F931B2FBE
+//	#5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8
+//
+// The PostgreSQL "md5" hashes are salted with the user name, which must be
+// given to NewPostgresMD5 with the WithUsername option.
+//
+// crypt.NewFromHash recognizes all of these formats, by their prefix or by the
+// shape of the hashed keys. The crypt.Crypter it returns for "md5" hashes has
+// no user name, and fails with ErrUsernameRequired.
+package dbauth
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"github.com/GehirnInc/crypt"
+)
+
+func init() {
+	crypt.RegisterCryptMatcher(crypt.MYSQL, NewMySQLNative, isMySQLNative)
+	crypt.RegisterCrypt(crypt.MYSQL_SHA2, NewMySQLCachingSHA2, MagicPrefixCachingSHA2)
+	crypt.RegisterCryptMatcher(crypt.POSTGRES_MD5,
+		func() crypt.Crypter { return NewPostgresMD5() }, isPostgresMD5)
+	crypt.RegisterCrypt(crypt.POSTGRES_SCRAM, NewPostgresSCRAM, MagicPrefixSCRAM)
+	crypt.RegisterCryptMatcher(crypt.ORACLE, NewOracle, isOracle)
+	crypt.RegisterCryptMatcher(crypt.MSSQL, NewMSSQL, isMSSQL)
+	crypt.RegisterCryptMatcher(crypt.REDIS, NewRedis, isRedis)
+}
+
+var ErrUsernameRequired = errors.New("dbauth: the user name is required")
+
+// An Option sets a parameter of a crypt.Crypter that is not found in its
+// hashed keys.
+type Option func(*options)
+
+type options struct {
+	username []byte
+}
+
+// WithUsername sets the name of the user whose password is hashed.
+func WithUsername(username string) Option {
+	return func(o *options) { o.username = []byte(username) }
+}
+
+// hexDigits are the hexadecimal digits of either case.
+const hexDigits = "0123456789ABCDEFabcdef"
+
+// isHex reports whether s is made of the given hexadecimal digits only.
+func isHex(s, digits string) bool {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(digits, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// upperHex returns src in uppercase hexadecimal.
+func upperHex(src []byte) []byte {
+	return bytes.ToUpper([]byte(hex.EncodeToString(src)))
+}
+
+// equalFold reports, in constant time, whether the hashed keys a and b are
+// equal regardless of the case of their hexadecimal digits.
+func equalFold(a, b string) bool {
+	return subtle.ConstantTimeCompare(bytes.ToUpper([]byte(a)), bytes.ToUpper([]byte(b))) == 1
+}
@@ -0,0 +1,360 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package dbauth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+var (
+	mysqlNativeCrypt = NewMySQLNative()
+	cachingSHA2Crypt = NewMySQLCachingSHA2()
+	postgresMD5Crypt = NewPostgresMD5(WithUsername("postgres"))
+	scramCrypt       = NewPostgresSCRAM()
+	oracleCrypt      = NewOracle()
+	mssqlCrypt       = NewMSSQL()
+	redisCrypt       = NewRedis()
+)
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		crypter crypt.Crypter
+		salt    []byte
+		key     []byte
+		out     string
+		cost    int
+	}{
+		{
+			mysqlNativeCrypt,
+			nil,
+			[]byte("password"),
+			"*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19",
+			1,
+		},
+		{
+			mysqlNativeCrypt,
+			[]byte("*FCF7C1B8749CF99D88E5F34271D636178FB5D130"),
+			[]byte("hashcat"),
+			"*FCF7C1B8749CF99D88E5F34271D636178FB5D130",
+			1,
+		},
+		{
+			cachingSHA2Crypt,
+			[]byte("$A$005$saltsaltsaltsaltsalt"),
+			[]byte("password"),
+			"$A$005$saltsaltsaltsaltsalt5SZd752QTW8/mT/5h.Rqvp/3mfPJ3Ut06Xumw96laKC",
+			5000,
+		},
+		{
+			cachingSHA2Crypt,
+			[]byte("$A$00A$S2aDd7xJRaGpklwEs19VK4j/v4PH.9SGHIdelFIeP9466gi8PKHPrpAVbiEIkj/"),
+			[]byte("hashcat"),
+			"$A$00A$S2aDd7xJRaGpklwEs19VK4j/v4PH.9SGHIdelFIeP9466gi8PKHPrpAVbiEIkj/",
+			10000,
+		},
+		{
+			postgresMD5Crypt,
+			nil,
+			[]byte("password"),
+			"md532e12f215ba27cb750c9e093ce4b5127",
+			1,
+		},
+		{
+			NewPostgresMD5(WithUsername("alice")),
+			[]byte("md5"),
+			[]byte("hashcat"),
+			"md5b1d3cb625fef28b55833e5cdf7a7fa64",
+			1,
+		},
+		{
+			scramCrypt,
+			[]byte("SCRAM-SHA-256$4096:c2FsdHNhbHRzYWx0c2FsdA=="),
+			[]byte("password"),
+			"SCRAM-SHA-256$4096:c2FsdHNhbHRzYWx0c2FsdA==$CozjiHjNmiMjBgH9gZ7qn0QWud6nrVP6E72IBh477bQ=:VKers2x8MllK1Rh7LZLqtj6KOTzoFWJpIaokMX3blS0=",
+			4096,
+		},
+		{
+			scramCrypt,
+			[]byte("SCRAM-SHA-256$10000:c2FsdA==$sxRkoRz6GrxFQQ4ZJqoh+v0AHVXLYj0Sruh6Q7XB+iU=:9lvSfxrGe5Q2g4N1AQH+pNIewLI977/uHy51a79DK6Y="),
+			[]byte("hashcat"),
+			"SCRAM-SHA-256$10000:c2FsdA==$sxRkoRz6GrxFQQ4ZJqoh+v0AHVXLYj0Sruh6Q7XB+iU=:9lvSfxrGe5Q2g4N1AQH+pNIewLI977/uHy51a79DK6Y=",
+			10000,
+		},
+		{
+			oracleCrypt,
+			[]byte("S:AC5F1E62D21FD0529428B84D42E8955B0496670338445748184477378130"),
+			[]byte("hashcat"),
+			"S:AC5F1E62D21FD0529428B84D42E8955B0496670338445748184477378130",
+			1,
+		},
+		{
+			oracleCrypt,
+			[]byte("S:73616C7473616C747361"),
+			[]byte("password"),
+			"S:DE15864CD8EBFF1B4049956E2EC4833746C2D91C73616C7473616C747361",
+			1,
+		},
+		{
+			oracleCrypt,
+			[]byte("T:78281A9C0CF626BD05EFC4F41B515B61D6C4D95A250CD4A605CA0EF97168D670EBCB5673B6F5A2FB9CC4E0C0101E659C0C4E3B9B3BEDA846CD15508E88685A2334141655046766111066420254008225"),
+			[]byte("hashcat"),
+			"T:78281A9C0CF626BD05EFC4F41B515B61D6C4D95A250CD4A605CA0EF97168D670EBCB5673B6F5A2FB9CC4E0C0101E659C0C4E3B9B3BEDA846CD15508E88685A2334141655046766111066420254008225",
+			RoundsOracle12c,
+		},
+		{
+			oracleCrypt,
+			[]byte("T:000102030405060708090a0b0c0d0e0f"),
+			[]byte("password"),
+			"T:ED373C8F16DA76F7B1DAC8E4374150DF6C44C52B4E36B2B905DD623A0082FABBEE9D0E74EF06D0FCD5B3A51A06654AE9AD4BBC14961A3F67676CEC68495FD234000102030405060708090A0B0C0D0E0F",
+			RoundsOracle12c,
+		},
+		{
+			mssqlCrypt,
+			[]byte("0x010018102152f8f28c8499d8ef263c53f8be369d799f931b2fbe"),
+			[]byte("hashcat"),
+			"0x010018102152F8F28C8499D8EF263C53F8BE369D799F931B2FBE",
+			1,
+		},
+		{
+			mssqlCrypt,
+			[]byte("0x02000102030434ea1b17802fd95ea6316bd61d2c94622ca3812793e8fb1672487b5c904a45a31b2ab4a78890d563d2fcf5663e46fe797d71550494be50cf4915d3f4d55ec375"),
+			[]byte("hashcat"),
+			"0x02000102030434EA1B17802FD95EA6316BD61D2C94622CA3812793E8FB1672487B5C904A45A31B2AB4A78890D563D2FCF5663E46FE797D71550494BE50CF4915D3F4D55EC375",
+			1,
+		},
+		{
+			mssqlCrypt,
+			[]byte("0x020073616C74"),
+			[]byte("password"),
+			"0x020073616C747C990AF29E2983FD04C0C6C461149A787C526ECB64C13326FE6C33A3D35F6AB03E274CF5C22C943456595E7E031117E8304A1CC233DAD23814AA8FF863A98566",
+			1,
+		},
+		{
+			redisCrypt,
+			nil,
+			[]byte("password"),
+			"#5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+			1,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := d.crypter.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := d.crypter.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []struct {
+		crypter crypt.Crypter
+		salt    string
+	}{
+		{mysqlNativeCrypt, "$A$005$"},
+		{cachingSHA2Crypt, "$A$005$saltsaltsaltsalt"},
+		{cachingSHA2Crypt, "$A$005$saltsaltsaltsaltsalt$"},
+		{cachingSHA2Crypt, "$A$004$saltsaltsaltsaltsalt"},
+		{cachingSHA2Crypt, "$A$00a$saltsaltsaltsaltsalt"},
+		{cachingSHA2Crypt, "$A$005$salt$altsaltsaltsalt"},
+		{cachingSHA2Crypt, "$5$005$saltsaltsaltsaltsalt"},
+		{postgresMD5Crypt, "SCRAM-SHA-256$"},
+		{scramCrypt, "SCRAM-SHA-256$4096"},
+		{scramCrypt, "SCRAM-SHA-256$0:c2FsdA=="},
+		{scramCrypt, "SCRAM-SHA-256$04096:c2FsdA=="},
+		{scramCrypt, "SCRAM-SHA-256$4096:c2FsdA"},
+		{scramCrypt, "SCRAM-SHA-256$4096:"},
+		{scramCrypt, "SCRAM-SHA-1$4096:c2FsdA=="},
+		{oracleCrypt, "S:73616C7473616C7473"},
+		{oracleCrypt, "S:73616C7473616C74736G"},
+		{oracleCrypt, "T:73616C7473616C747361"},
+		{oracleCrypt, "H:73616C7473616C747361"},
+		{mssqlCrypt, "0x0300AABBCCDD"},
+		{mssqlCrypt, "0x0200AABBCC"},
+		{mssqlCrypt, "0x0200AABBCCDG"},
+		{mssqlCrypt, "0x0100AABBCCDD00"},
+		{redisCrypt, "*5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"},
+	}
+	for i, d := range data {
+		if _, err := d.crypter.Generate([]byte("password"), []byte(d.salt)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d.salt)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	salt, err := GenerateSaltCachingSHA2(0xabc * 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(salt), "$A$ABC$") || len(salt) != 7+SaltLenCachingSHA2 {
+		t.Errorf("unexpected setting %s", salt)
+	}
+	for _, rounds := range []int{4000, 5500, RoundsMaxCachingSHA2 + 1000} {
+		if _, err := GenerateSaltCachingSHA2(rounds); err != common.ErrSaltRounds {
+			t.Errorf("%d rounds were accepted", rounds)
+		}
+	}
+
+	salt, err = GenerateSaltSCRAM(10000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(salt), "SCRAM-SHA-256$10000:") {
+		t.Errorf("unexpected setting %s", salt)
+	}
+	if _, err := GenerateSaltSCRAM(0); err != common.ErrSaltRounds {
+		t.Errorf("0 rounds were accepted")
+	}
+}
+
+func TestPostgresMD5Username(t *testing.T) {
+	c := NewPostgresMD5()
+	if _, err := c.Generate([]byte("password"), nil); err != ErrUsernameRequired {
+		t.Errorf("Expected: %v, got: %v", ErrUsernameRequired, err)
+	}
+	if err := c.Verify("md532e12f215ba27cb750c9e093ce4b5127", []byte("password")); err != ErrUsernameRequired {
+		t.Errorf("Expected: %v, got: %v", ErrUsernameRequired, err)
+	}
+	if err := NewPostgresMD5(WithUsername("alice")).Verify("md532e12f215ba27cb750c9e093ce4b5127", []byte("password")); err != crypt.ErrKeyMismatch {
+		t.Errorf("Expected: %v, got: %v", crypt.ErrKeyMismatch, err)
+	}
+}
+
+func TestIsHashSupported(t *testing.T) {
+	data := []string{
+		"*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E19",
+		"$A$005$saltsaltsaltsaltsalt5SZd752QTW8/mT/5h.Rqvp/3mfPJ3Ut06Xumw96laKC",
+		"md532e12f215ba27cb750c9e093ce4b5127",
+		"SCRAM-SHA-256$4096:c2FsdA==$sxRkoRz6GrxFQQ4ZJqoh+v0AHVXLYj0Sruh6Q7XB+iU=:9lvSfxrGe5Q2g4N1AQH+pNIewLI977/uHy51a79DK6Y=",
+		"S:AC5F1E62D21FD0529428B84D42E8955B0496670338445748184477378130",
+		"T:78281A9C0CF626BD05EFC4F41B515B61D6C4D95A250CD4A605CA0EF97168D670EBCB5673B6F5A2FB9CC4E0C0101E659C0C4E3B9B3BEDA846CD15508E88685A2334141655046766111066420254008225",
+		"0x010018102152f8f28c8499d8ef263c53f8be369d799f931b2fbe",
+		"0x020073616C747C990AF29E2983FD04C0C6C461149A787C526ECB64C13326FE6C33A3D35F6AB03E274CF5C22C943456595E7E031117E8304A1CC233DAD23814AA8FF863A98566",
+		"#5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+	}
+	for i, hash := range data {
+		if !crypt.IsHashSupported(hash) {
+			t.Errorf("Test %d failed: %s is not supported", i, hash)
+		}
+	}
+	for _, hash := range []string{
+		"*2470c0c06dee42fd1618bb99005adca2ec9d1e19",
+		"*2470C0C06DEE42FD1618BB99005ADCA2EC9D1E1",
+		"md532E12F215BA27CB750C9E093CE4B5127",
+		"md532e12f215ba27cb750c9e093ce4b51",
+		"S:73616C7473616C747361",
+		"T:AC5F1E62D21FD0529428B84D42E8955B0496670338445748184477378130",
+		"0x020073616C74",
+		"0x010018102152f8f28c8499d8ef263c53f8be369d799f931b2fbx",
+		"#5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d",
+	} {
+		if crypt.IsHashSupported(hash) {
+			t.Errorf("%s is supported", hash)
+		}
+	}
+}
+
+func TestVerifyCase(t *testing.T) {
+	data := []struct {
+		crypter crypt.Crypter
+		hash    string
+	}{
+		{oracleCrypt, "S:ac5f1e62d21fd0529428b84d42e8955b0496670338445748184477378130"},
+		{mssqlCrypt, "0x010018102152f8f28c8499d8ef263c53f8be369d799f931b2fbe"},
+		{redisCrypt, "#127E6FBFE24A750E72930C220A8E138275656B8E5D8F48A98C3C92DF2CABA935"},
+	}
+	for i, d := range data {
+		if err := d.crypter.Verify(d.hash, []byte("hashcat")); err != nil {
+			t.Errorf("Test %d failed: %v", i, err)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of the hashcat examples, and of the schemes of MySQL,
+	// PostgreSQL, Oracle, SQL Server and Redis computed with Python's
+	// hashlib.
+	data := []struct {
+		crypter crypt.Crypter
+		hash    string
+		key     []byte
+	}{
+		{mysqlNativeCrypt, "*FCF7C1B8749CF99D88E5F34271D636178FB5D130", []byte("hashcat")},
+		{mysqlNativeCrypt, "*14E65567ABDB5135D0CFD9A70B3032C179A49EE7", []byte("secret")},
+		{mysqlNativeCrypt, "*F4AF2E5D85456A908E0F552F0366375B06267295", []byte("correct horse battery staple")},
+		{cachingSHA2Crypt, "$A$00A$S2aDd7xJRaGpklwEs19VK4j/v4PH.9SGHIdelFIeP9466gi8PKHPrpAVbiEIkj/", []byte("hashcat")},
+		{cachingSHA2Crypt, "$A$005$Jb5zzTJ2sMVUaLswVqr7rzoPzGvLmSPT6dCM0KVlAKEgOVA2cJbCcZcJ5cdBEE4", []byte("secret")},
+		{cachingSHA2Crypt, "$A$00A$x0Nq1Zb/LK.ay7qEQmB8ulq4f2iSoJLnAKOWQnk7OhOOcgRbpfYaOdx8kq0hM84", []byte("correct horse battery staple")},
+		{postgresMD5Crypt, "md553f48b7c4b76a86ce72276c5755f217d", []byte("secret")},
+		{postgresMD5Crypt, "md5e19565867566a5bc4c878a28d4424685", []byte("correct horse battery staple")},
+		{scramCrypt, "SCRAM-SHA-256$10000:c2FsdA==$sxRkoRz6GrxFQQ4ZJqoh+v0AHVXLYj0Sruh6Q7XB+iU=:9lvSfxrGe5Q2g4N1AQH+pNIewLI977/uHy51a79DK6Y=", []byte("hashcat")},
+		{scramCrypt, "SCRAM-SHA-256$4096:ey88Hp1KW2yODxorPE1ebw==$9o7COXCWh61hyErReIQxj9uq2BBk/C6HBmqvul/UuF0=:4EvdmWcfJPcDdZuJEO8FZVbUoXpa9FjplFdlJPNvlac=", []byte("secret")},
+		{scramCrypt, "SCRAM-SHA-256$10000:obLD1OX2BxgpOktcbX6PkA==$7qRw8JGMKMoDK+38X23h3wb9xtvx1X2jtPgUDaKa7eE=:XlzyOi1D4L9mPkBiyWuCMUbFVHM/V4BFLvNsyAX4VgY=", []byte("correct horse battery staple")},
+		{oracleCrypt, "S:AC5F1E62D21FD0529428B84D42E8955B0496670338445748184477378130", []byte("hashcat")},
+		{oracleCrypt, "S:8B36A174ADBC1F9C47631743C0007ACF825EE3CB8D7E6F5A4B3C2D1E0F10", []byte("secret")},
+		{oracleCrypt, "S:A6322CD3BE6B9F6C991110874C4A490E76D0E8260123456789ABCDEF0123", []byte("correct horse battery staple")},
+		{oracleCrypt, "T:78281A9C0CF626BD05EFC4F41B515B61D6C4D95A250CD4A605CA0EF97168D670EBCB5673B6F5A2FB9CC4E0C0101E659C0C4E3B9B3BEDA846CD15508E88685A2334141655046766111066420254008225", []byte("hashcat")},
+		{oracleCrypt, "T:68ED46ECACA8CBF913E6A9B002F01DB2D372DE706979E7C9EB44EACA0E70FBCAD8291610B6DE9DA6A9D9733DD763F7DB9DD58E691D77C3B3C4E4313EC54601168D7E6F5A4B3C2D1E0F1021324354AB65", []byte("secret")},
+		{oracleCrypt, "T:10C5489CB1DCD99CB569154DF686BBBC73EE41DDA7F98AEB8182C54CE321831D363CFF5AEE728BAD7070F635FE0CDED7242F149A89730DA5F0823FCF31035080F0E1D2C3B4A5968778695A4B3C2D1E0F", []byte("correct horse battery staple")},
+		{mssqlCrypt, "0x010018102152F8F28C8499D8EF263C53F8BE369D799F931B2FBE", []byte("hashcat")},
+		{mssqlCrypt, "0x01001A2B3C4D69D4B50FEC7CB628223B7FA1B786BB01B6499E71", []byte("secret")},
+		{mssqlCrypt, "0x0100DEADBEEF9B51D0070C4DC1DAEB56FBBCCE4CFF987920889F", []byte("correct horse battery staple")},
+		{mssqlCrypt, "0x02000102030434EA1B17802FD95EA6316BD61D2C94622CA3812793E8FB1672487B5C904A45A31B2AB4A78890D563D2FCF5663E46FE797D71550494BE50CF4915D3F4D55EC375", []byte("hashcat")},
+		{mssqlCrypt, "0x02001A2B3C4D0D929B787BD13A376E0355162EBCA4285CB576C9AC74C15F2C65AC43502FBDDFC0AD3AC1CA68032558A0584472F8A2073CEFEB60A7240D63E36C850BEF6BC3A7", []byte("secret")},
+		{mssqlCrypt, "0x0200DEADBEEFFF0E3EB9BBE4ED84DF937095469D1617BE58924C7109A7FF0C8855811F2ACD3E3A81A1BBD8A31E0CA275AD86AD534259F6B3F0F00F661AC2A82DEFF7CFD62938", []byte("correct horse battery staple")},
+		{redisCrypt, "#127e6fbfe24a750e72930c220a8e138275656b8e5d8f48a98c3c92df2caba935", []byte("hashcat")},
+		{redisCrypt, "#2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b", []byte("secret")},
+		{redisCrypt, "#c4bbcb1fbec99d65bf59d85c8cb62ee2db963f0fe106f483d9afa73bd4e39a8a", []byte("correct horse battery staple")},
+	}
+	for i, d := range data {
+		if err := d.crypter.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := d.crypter.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	crypters := []struct {
+		crypter crypt.Crypter
+		prefix  string
+	}{
+		{mysqlNativeCrypt, MagicPrefixMySQLNative},
+		{cachingSHA2Crypt, "$A$005$"},
+		{postgresMD5Crypt, MagicPrefixPostgresMD5},
+		{scramCrypt, "SCRAM-SHA-256$4096:"},
+		{oracleCrypt, MagicPrefixOracle12c},
+		{mssqlCrypt, MagicPrefixMSSQL2012},
+		{redisCrypt, MagicPrefixRedis},
+	}
+	for i, c := range crypters {
+		hash, err := c.crypter.Generate([]byte("password"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(hash, c.prefix) {
+			t.Errorf("Test %d failed: unexpected hash %s", i, hash)
+		}
+		if err = c.crypter.Verify(hash, []byte("password")); err != nil {
+			t.Errorf("Test %d failed: %s", i, hash)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package dbauth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+)
+
+const (
+	MagicPrefixMSSQL2005 = "0x0100"
+	MagicPrefixMSSQL2012 = "0x0200"
+	SaltLenMSSQL         = 4 // in bytes
+)
+
+var mssqlHashes = map[string]func() hash.Hash{
+	MagicPrefixMSSQL2005: sha1.New,
+	MagicPrefixMSSQL2012: sha512.New,
+}
+
+type mssqlCrypter struct{ Salt common.Salt }
+
+// NewMSSQL returns a new crypt.Crypter computing the password hashes of SQL
+// Server, as returned by PWDENCRYPT and stored in sys.sql_logins:
+//
+//	0x010018102152F8F28C8499D8EF263C53F8BE369D799F931B2FBE
+//
+// They are made of a version header, a salt of SaltLenMSSQL bytes and the
+// checksum of the key encoded in UTF-16LE followed by the salt, in
+// hexadecimal. The checksum is SHA-1 for the "0x0100" header of SQL Server
+// 2005 and 2008, and SHA-512 for the "0x0200" header of SQL Server 2012 and
+// later, which is used when no salt is given. The longer "0x0100" hashes of
+// SQL Server 2000 are not supported.
+func NewMSSQL() crypt.Crypter {
+	return &mssqlCrypter{
+		common.Salt{
+			MagicPrefix: []byte(MagicPrefixMSSQL2012),
+			SaltLenMin:  SaltLenMSSQL,
+			SaltLenMax:  SaltLenMSSQL,
+		},
+	}
+}
+
+// Generate returns the hash of the key. The salt is either a hash, or the
+// version header followed by the salt in hexadecimal.
+func (c *mssqlCrypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		rawSalt := make([]byte, SaltLenMSSQL)
+		rand.Read(rawSalt)
+		salt = append(append([]byte{}, c.Salt.MagicPrefix...), hex.EncodeToString(rawSalt)...)
+	}
+	prefix, h, rawSalt, err := decodeMSSQL(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key = internal.UTF16LE(key)
+	hh := h()
+	hh.Write(key)
+	hh.Write(rawSalt)
+	internal.CleanSensitiveData(key)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(prefix) + 2*SaltLenMSSQL + 2*hh.Size())
+	buf.Write(prefix)
+	buf.Write(upperHex(rawSalt))
+	buf.Write(upperHex(hh.Sum(nil)))
+	return buf.String(), nil
+}
+
+// Verify compares the hexadecimal digits of the hashes regardless of their
+// case.
+func (c *mssqlCrypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if !equalFold(newHash, hashedKey) {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+func (c *mssqlCrypter) Cost(hashedKey string) (int, error) {
+	if _, _, _, err := decodeMSSQL([]byte(hashedKey)); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+func (c *mssqlCrypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decodeMSSQL returns the version header, the hash function and the salt of
+// raw, which is either a hash or a setting.
+func decodeMSSQL(raw []byte) (prefix []byte, h func() hash.Hash, salt []byte, err error) {
+	if len(raw) < len(MagicPrefixMSSQL2012) {
+		return nil, nil, nil, common.ErrSaltPrefix
+	}
+	prefix = raw[:len(MagicPrefixMSSQL2012)]
+	h, ok := mssqlHashes[string(prefix)]
+	if !ok {
+		return nil, nil, nil, common.ErrSaltPrefix
+	}
+
+	rest := raw[len(prefix):]
+	if len(rest) != 2*SaltLenMSSQL && len(rest) != 2*SaltLenMSSQL+2*h().Size() {
+		return nil, nil, nil, common.ErrSaltFormat
+	}
+	if salt, err = hex.DecodeString(string(rest[:2*SaltLenMSSQL])); err != nil {
+		return nil, nil, nil, common.ErrSaltFormat
+	}
+	return prefix, h, salt, nil
+}
+
+// isMSSQL reports whether hashedKey is a complete hash.
+func isMSSQL(hashedKey string) bool {
+	_, h, _, err := decodeMSSQL([]byte(hashedKey))
+	return err == nil && len(hashedKey) == len(MagicPrefixMSSQL2012)+2*SaltLenMSSQL+2*h().Size() &&
+		isHex(hashedKey[len(MagicPrefixMSSQL2012):], hexDigits)
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package dbauth
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/subtle"
+	"strconv"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/sha256_crypt"
+)
+
+const (
+	MagicPrefixMySQLNative = "*"
+
+	MagicPrefixCachingSHA2 = "$A$"
+	SaltLenCachingSHA2     = 20
+	RoundsMinCachingSHA2   = 5000
+	RoundsMaxCachingSHA2   = 0xfff * 1000
+	RoundsCachingSHA2      = 5000
+)
+
+const (
+	cachingSHA2SettingLen = len(MagicPrefixCachingSHA2) + 3 + 1 + SaltLenCachingSHA2
+	cachingSHA2SumLen     = 43
+)
+
+type mysqlNativeCrypter struct{ Salt common.Salt }
+
+// NewMySQLNative returns a new crypt.Crypter computing the hashes of the
+// mysql_native_password plugin, that is the SHA-1 checksum of the SHA-1
+// checksum of the key, in uppercase hexadecimal. They have no salt.
+func NewMySQLNative() crypt.Crypter {
+	return &mysqlNativeCrypter{
+		common.Salt{MagicPrefix: []byte(MagicPrefixMySQLNative)},
+	}
+}
+
+// Generate returns the hash of the key. The salt, if any, must only have the
+// magic prefix; the rest of it is ignored.
+func (c *mysqlNativeCrypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) != 0 && !bytes.HasPrefix(salt, c.Salt.MagicPrefix) {
+		return "", common.ErrSaltPrefix
+	}
+	stage1 := sha1.Sum(key)
+	stage2 := sha1.Sum(stage1[:])
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(c.Salt.MagicPrefix) + 2*sha1.Size)
+	buf.Write(c.Salt.MagicPrefix)
+	buf.Write(upperHex(stage2[:]))
+	return buf.String(), nil
+}
+
+func (c *mysqlNativeCrypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+func (c *mysqlNativeCrypter) Cost(hashedKey string) (int, error) { return 1, nil }
+
+func (c *mysqlNativeCrypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// isMySQLNative reports whether hashedKey is a mysql_native_password hash.
+func isMySQLNative(hashedKey string) bool {
+	return len(hashedKey) == len(MagicPrefixMySQLNative)+2*sha1.Size &&
+		hashedKey[:len(MagicPrefixMySQLNative)] == MagicPrefixMySQLNative &&
+		isHex(hashedKey[len(MagicPrefixMySQLNative):], "0123456789ABCDEF")
+}
+
+// GenerateSaltCachingSHA2 returns a caching_sha2_password setting with the
+// given number of rounds, which must be a multiple of 1000, and a random salt
+// of SaltLenCachingSHA2 alphanumeric characters.
+func GenerateSaltCachingSHA2(rounds int) ([]byte, error) {
+	if rounds < RoundsMinCachingSHA2 || rounds > RoundsMaxCachingSHA2 || rounds%1000 != 0 {
+		return nil, common.ErrSaltRounds
+	}
+	return generateSaltCachingSHA2(rounds), nil
+}
+
+func generateSaltCachingSHA2(rounds int) []byte {
+	count := strconv.FormatUint(uint64(rounds/1000), 16)
+
+	buf := bytes.Buffer{}
+	buf.Grow(cachingSHA2SettingLen)
+	buf.WriteString(MagicPrefixCachingSHA2)
+	buf.WriteString("000"[len(count):])
+	buf.Write(bytes.ToUpper([]byte(count)))
+	buf.WriteByte('$')
+	buf.Write(internal.RandomString(saltAlphabet, SaltLenCachingSHA2))
+	return buf.Bytes()
+}
+
+type cachingSHA2Crypter struct{ Salt common.Salt }
+
+// NewMySQLCachingSHA2 returns a new crypt.Crypter computing the hashes of the
+// caching_sha2_password plugin, which are SHA256-crypt hashes with a salt of
+// SaltLenCachingSHA2 bytes and a number of rounds written as a count of
+// thousands in three hexadecimal digits.
+func NewMySQLCachingSHA2() crypt.Crypter {
+	return &cachingSHA2Crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefixCachingSHA2),
+			SaltLenMin:    SaltLenCachingSHA2,
+			SaltLenMax:    SaltLenCachingSHA2,
+			RoundsMin:     RoundsMinCachingSHA2,
+			RoundsMax:     RoundsMaxCachingSHA2,
+			RoundsDefault: RoundsCachingSHA2,
+		},
+	}
+}
+
+func (c *cachingSHA2Crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		salt = generateSaltCachingSHA2(RoundsCachingSHA2)
+	}
+	setting, rounds, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+	rawSalt := setting[cachingSHA2SettingLen-SaltLenCachingSHA2:]
+
+	// The checksum is the one of SHA256-crypt, whose salts are longer here.
+	sha256Crypter := sha256_crypt.New()
+	sha256Crypter.SetSalt(common.Salt{
+		MagicPrefix:   []byte(sha256_crypt.MagicPrefix),
+		SaltLenMin:    SaltLenCachingSHA2,
+		SaltLenMax:    SaltLenCachingSHA2,
+		RoundsMin:     RoundsMinCachingSHA2,
+		RoundsMax:     RoundsMaxCachingSHA2,
+		RoundsDefault: sha256_crypt.RoundsDefault,
+	})
+	sha256Setting := sha256_crypt.MagicPrefix + "rounds=" + strconv.Itoa(rounds) + "$" + string(rawSalt)
+	sha256Hash, err := sha256Crypter.Generate(key, []byte(sha256Setting))
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	buf.Grow(cachingSHA2SettingLen + cachingSHA2SumLen)
+	buf.Write(setting)
+	buf.WriteString(sha256Hash[len(sha256Hash)-cachingSHA2SumLen:])
+	return buf.String(), nil
+}
+
+func (c *cachingSHA2Crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the number of rounds of SHA256-crypt.
+func (c *cachingSHA2Crypter) Cost(hashedKey string) (int, error) {
+	_, rounds, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return rounds, nil
+}
+
+func (c *cachingSHA2Crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decode returns the setting part of raw, "$A$005$" followed by the salt, and
+// the number of rounds. The salt is made of any bytes but NUL and '$', as
+// MySQL generates.
+func (c *cachingSHA2Crypter) decode(raw []byte) (setting []byte, rounds int, err error) {
+	if !bytes.HasPrefix(raw, c.Salt.MagicPrefix) {
+		return nil, 0, common.ErrSaltPrefix
+	}
+	if len(raw) != cachingSHA2SettingLen && len(raw) != cachingSHA2SettingLen+cachingSHA2SumLen {
+		return nil, 0, common.ErrSaltFormat
+	}
+	setting = raw[:cachingSHA2SettingLen]
+
+	count := setting[len(MagicPrefixCachingSHA2) : len(MagicPrefixCachingSHA2)+3]
+	if setting[len(MagicPrefixCachingSHA2)+3] != '$' || !isHex(string(count), "0123456789ABCDEF") {
+		return nil, 0, common.ErrSaltFormat
+	}
+	n, _ := strconv.ParseUint(string(count), 16, 16)
+	rounds = int(n) * 1000
+	if rounds < c.Salt.RoundsMin || rounds > c.Salt.RoundsMax {
+		return nil, 0, common.ErrSaltRounds
+	}
+
+	salt := setting[len(setting)-SaltLenCachingSHA2:]
+	if bytes.IndexByte(salt, '$') >= 0 || bytes.IndexByte(salt, 0) >= 0 {
+		return nil, 0, common.ErrSaltFormat
+	}
+	return setting, rounds, nil
+}
+
+const saltAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
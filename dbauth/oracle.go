@@ -0,0 +1,168 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package dbauth
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha512"
+	"encoding/hex"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+const (
+	MagicPrefixOracle11g = "S:"
+	MagicPrefixOracle12c = "T:"
+	SaltLenOracle11g     = 10 // in bytes
+	SaltLenOracle12c     = 16 // in bytes
+	RoundsOracle12c      = 4096
+)
+
+// oracleSpeedyKey is appended to the salt of the 12c verifiers.
+var oracleSpeedyKey = []byte("AUTH_PBKDF2_SPEEDY_KEY")
+
+type oracleVersion struct {
+	saltLen int
+	sumLen  int
+	cost    int
+	sum     func(key, salt []byte) []byte
+}
+
+var oracleVersions = map[string]oracleVersion{
+	MagicPrefixOracle11g: {
+		saltLen: SaltLenOracle11g,
+		sumLen:  sha1.Size,
+		cost:    1,
+		sum: func(key, salt []byte) []byte {
+			h := sha1.New()
+			h.Write(key)
+			h.Write(salt)
+			return h.Sum(nil)
+		},
+	},
+	MagicPrefixOracle12c: {
+		saltLen: SaltLenOracle12c,
+		sumLen:  sha512.Size,
+		cost:    RoundsOracle12c,
+		sum: func(key, salt []byte) []byte {
+			speedySalt := append(append([]byte{}, salt...), oracleSpeedyKey...)
+			dk := pbkdf2.SHA512(key, speedySalt, RoundsOracle12c, sha512.Size)
+			h := sha512.New()
+			h.Write(dk)
+			h.Write(salt)
+			internal.CleanSensitiveData(dk)
+			return h.Sum(nil)
+		},
+	},
+}
+
+type oracleCrypter struct{ Salt common.Salt }
+
+// NewOracle returns a new crypt.Crypter computing the password verifiers of
+// Oracle Database, as found in the SPARE4 column of SYS.USER$:
+//
+//	S:AC5F1E62D21FD0529428B84D42E8955B0496670338445748184477378130
+//	T:<64-byte checksum><16-byte salt>
+//
+// The 11g "S:" verifiers are the SHA-1 checksum of the key followed by the
+// salt, and the 12c "T:" ones the SHA-512 checksum of a PBKDF2-HMAC-SHA512
+// derived key followed by the salt. Both are written in hexadecimal, the
+// checksum first. A SPARE4 value holding several verifiers separated by ';'
+// must be split beforehand. When no salt is given, it generates 12c
+// verifiers.
+func NewOracle() crypt.Crypter {
+	return &oracleCrypter{
+		common.Salt{
+			MagicPrefix: []byte(MagicPrefixOracle12c),
+			SaltLenMin:  SaltLenOracle12c,
+			SaltLenMax:  SaltLenOracle12c,
+		},
+	}
+}
+
+// Generate returns the verifier of the key. The salt is either a verifier, or
+// the magic prefix followed by the salt in hexadecimal.
+func (c *oracleCrypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		v := oracleVersions[string(c.Salt.MagicPrefix)]
+		rawSalt := make([]byte, v.saltLen)
+		rand.Read(rawSalt)
+		salt = append(append([]byte{}, c.Salt.MagicPrefix...), hex.EncodeToString(rawSalt)...)
+	}
+	prefix, v, rawSalt, err := decodeOracle(salt)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(prefix) + 2*v.sumLen + 2*v.saltLen)
+	buf.Write(prefix)
+	buf.Write(upperHex(v.sum(key, rawSalt)))
+	buf.Write(upperHex(rawSalt))
+	return buf.String(), nil
+}
+
+// Verify compares the hexadecimal digits of the verifiers regardless of their
+// case.
+func (c *oracleCrypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if !equalFold(newHash, hashedKey) {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns 1 for 11g verifiers, and the number of PBKDF2 iterations for
+// 12c ones.
+func (c *oracleCrypter) Cost(hashedKey string) (int, error) {
+	_, v, _, err := decodeOracle([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return v.cost, nil
+}
+
+func (c *oracleCrypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decodeOracle returns the magic prefix, the version and the salt of raw,
+// which is either a verifier or a setting.
+func decodeOracle(raw []byte) (prefix []byte, v oracleVersion, salt []byte, err error) {
+	if len(raw) < len(MagicPrefixOracle11g) {
+		return nil, v, nil, common.ErrSaltPrefix
+	}
+	prefix = raw[:len(MagicPrefixOracle11g)]
+	v, ok := oracleVersions[string(prefix)]
+	if !ok {
+		return nil, v, nil, common.ErrSaltPrefix
+	}
+
+	rest := raw[len(prefix):]
+	switch len(rest) {
+	case 2 * v.saltLen:
+	case 2*v.sumLen + 2*v.saltLen:
+		rest = rest[2*v.sumLen:]
+	default:
+		return nil, v, nil, common.ErrSaltFormat
+	}
+	if salt, err = hex.DecodeString(string(rest)); err != nil {
+		return nil, v, nil, common.ErrSaltFormat
+	}
+	return prefix, v, salt, nil
+}
+
+// isOracle reports whether hashedKey is a complete 11g or 12c verifier.
+func isOracle(hashedKey string) bool {
+	_, v, _, err := decodeOracle([]byte(hashedKey))
+	return err == nil && len(hashedKey) == len(MagicPrefixOracle11g)+2*v.sumLen+2*v.saltLen &&
+		isHex(hashedKey[len(MagicPrefixOracle11g):], hexDigits)
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package dbauth
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"strconv"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+const (
+	MagicPrefixPostgresMD5 = "md5"
+
+	MagicPrefixSCRAM = "SCRAM-SHA-256$"
+	SaltLenMinSCRAM  = 1  // in bytes
+	SaltLenMaxSCRAM  = 16 // in bytes, for generated salts only
+	RoundsMinSCRAM   = 1
+	RoundsMaxSCRAM   = 1<<31 - 1
+	RoundsSCRAM      = 4096
+)
+
+type postgresMD5Crypter struct {
+	Salt common.Salt
+	options
+}
+
+// NewPostgresMD5 returns a new crypt.Crypter computing the "md5" hashes of
+// PostgreSQL, that is the MD5 checksum of the key followed by the user name, in
+// hexadecimal. The user name must be set with WithUsername, or Generate and
+// Verify return ErrUsernameRequired.
+func NewPostgresMD5(opts ...Option) crypt.Crypter {
+	c := &postgresMD5Crypter{
+		Salt: common.Salt{MagicPrefix: []byte(MagicPrefixPostgresMD5)},
+	}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	return c
+}
+
+// Generate returns the hash of the key. The salt, if any, must only have the
+// magic prefix; the rest of it is ignored.
+func (c *postgresMD5Crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) != 0 && !bytes.HasPrefix(salt, c.Salt.MagicPrefix) {
+		return "", common.ErrSaltPrefix
+	}
+	if len(c.username) == 0 {
+		return "", ErrUsernameRequired
+	}
+
+	h := md5.New()
+	h.Write(key)
+	h.Write(c.username)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(c.Salt.MagicPrefix) + 2*md5.Size)
+	buf.Write(c.Salt.MagicPrefix)
+	buf.WriteString(hex.EncodeToString(h.Sum(nil)))
+	return buf.String(), nil
+}
+
+func (c *postgresMD5Crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+func (c *postgresMD5Crypter) Cost(hashedKey string) (int, error) { return 1, nil }
+
+func (c *postgresMD5Crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// isPostgresMD5 reports whether hashedKey is a PostgreSQL "md5" hash.
+func isPostgresMD5(hashedKey string) bool {
+	return len(hashedKey) == len(MagicPrefixPostgresMD5)+2*md5.Size &&
+		hashedKey[:len(MagicPrefixPostgresMD5)] == MagicPrefixPostgresMD5 &&
+		isHex(hashedKey[len(MagicPrefixPostgresMD5):], "0123456789abcdef")
+}
+
+// GenerateSaltSCRAM returns a SCRAM-SHA-256 setting with the given number of
+// iterations and a random salt of SaltLenMaxSCRAM bytes.
+func GenerateSaltSCRAM(rounds int) ([]byte, error) {
+	if rounds < RoundsMinSCRAM || rounds > RoundsMaxSCRAM {
+		return nil, common.ErrSaltRounds
+	}
+	return generateSaltSCRAM(rounds), nil
+}
+
+func generateSaltSCRAM(rounds int) []byte {
+	salt := make([]byte, SaltLenMaxSCRAM)
+	rand.Read(salt)
+
+	buf := bytes.Buffer{}
+	buf.WriteString(MagicPrefixSCRAM)
+	buf.WriteString(strconv.Itoa(rounds))
+	buf.WriteByte(':')
+	buf.WriteString(pbkdf2.Base64.EncodeToString(salt))
+	return buf.Bytes()
+}
+
+type scramCrypter struct{ Salt common.Salt }
+
+// NewPostgresSCRAM returns a new crypt.Crypter computing the SCRAM-SHA-256
+// verifiers of PostgreSQL, made of the StoredKey and the ServerKey of RFC 5802
+// derived with PBKDF2-HMAC-SHA256.
+//
+// PostgreSQL normalizes non-ASCII passwords with SASLprep before hashing them,
+// which this package does not: such keys must be normalized by the caller.
+func NewPostgresSCRAM() crypt.Crypter {
+	return &scramCrypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefixSCRAM),
+			SaltLenMin:    SaltLenMinSCRAM,
+			SaltLenMax:    SaltLenMaxSCRAM,
+			RoundsMin:     RoundsMinSCRAM,
+			RoundsMax:     RoundsMaxSCRAM,
+			RoundsDefault: RoundsSCRAM,
+		},
+	}
+}
+
+func (c *scramCrypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		salt = generateSaltSCRAM(RoundsSCRAM)
+	}
+	setting, rounds, rawSalt, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	salted := pbkdf2.SHA256(key, rawSalt, rounds, sha256.Size)
+	mac := hmac.New(sha256.New, salted)
+	mac.Write([]byte("Client Key"))
+	storedKey := sha256.Sum256(mac.Sum(nil))
+	mac.Reset()
+	mac.Write([]byte("Server Key"))
+	serverKey := mac.Sum(nil)
+	internal.CleanSensitiveData(salted)
+
+	buf := bytes.Buffer{}
+	buf.Write(setting)
+	buf.WriteByte('$')
+	buf.WriteString(pbkdf2.Base64.EncodeToString(storedKey[:]))
+	buf.WriteByte(':')
+	buf.WriteString(pbkdf2.Base64.EncodeToString(serverKey))
+	return buf.String(), nil
+}
+
+func (c *scramCrypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the number of PBKDF2 iterations.
+func (c *scramCrypter) Cost(hashedKey string) (int, error) {
+	_, rounds, _, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return rounds, nil
+}
+
+func (c *scramCrypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decode splits raw, "SCRAM-SHA-256$<iterations>:<salt>" which may be followed
+// by "$<StoredKey>:<ServerKey>", into the setting part, the number of
+// iterations and the decoded salt.
+func (c *scramCrypter) decode(raw []byte) (setting []byte, rounds int, salt []byte, err error) {
+	if !bytes.HasPrefix(raw, c.Salt.MagicPrefix) {
+		return nil, 0, nil, common.ErrSaltPrefix
+	}
+	setting = raw
+	if i := bytes.IndexByte(raw[len(c.Salt.MagicPrefix):], '$'); i >= 0 {
+		setting = raw[:len(c.Salt.MagicPrefix)+i]
+	}
+
+	fields := bytes.Split(setting[len(c.Salt.MagicPrefix):], []byte(":"))
+	if len(fields) != 2 {
+		return nil, 0, nil, common.ErrSaltFormat
+	}
+	var ok bool
+	if rounds, ok = internal.ParseInt(fields[0]); !ok || rounds < c.Salt.RoundsMin || rounds > c.Salt.RoundsMax {
+		return nil, 0, nil, common.ErrSaltRounds
+	}
+	if salt, err = pbkdf2.Base64.DecodeString(string(fields[1])); err != nil || len(salt) < c.Salt.SaltLenMin {
+		return nil, 0, nil, common.ErrSaltFormat
+	}
+	return setting, rounds, salt, nil
+}
@@ -0,0 +1,69 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package dbauth
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+const MagicPrefixRedis = "#"
+
+type redisCrypter struct{ Salt common.Salt }
+
+// NewRedis returns a new crypt.Crypter computing the password hashes of the
+// Redis ACL, that is the SHA-256 checksum of the key in hexadecimal:
+//
+//	#5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8
+//
+// They have no salt.
+func NewRedis() crypt.Crypter {
+	return &redisCrypter{
+		common.Salt{MagicPrefix: []byte(MagicPrefixRedis)},
+	}
+}
+
+// Generate returns the hash of the key. The salt, if any, must only have the
+// magic prefix; the rest of it is ignored.
+func (c *redisCrypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) != 0 && !bytes.HasPrefix(salt, c.Salt.MagicPrefix) {
+		return "", common.ErrSaltPrefix
+	}
+	sum := sha256.Sum256(key)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(c.Salt.MagicPrefix) + 2*sha256.Size)
+	buf.Write(c.Salt.MagicPrefix)
+	buf.WriteString(hex.EncodeToString(sum[:]))
+	return buf.String(), nil
+}
+
+// Verify compares the hexadecimal digits of the hashes regardless of their
+// case.
+func (c *redisCrypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if !equalFold(newHash, hashedKey) {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+func (c *redisCrypter) Cost(hashedKey string) (int, error) { return 1, nil }
+
+func (c *redisCrypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// isRedis reports whether hashedKey is a Redis ACL hash.
+func isRedis(hashedKey string) bool {
+	return len(hashedKey) == len(MagicPrefixRedis)+2*sha256.Size &&
+		hashedKey[:len(MagicPrefixRedis)] == MagicPrefixRedis &&
+		isHex(hashedKey[len(MagicPrefixRedis):], hexDigits)
+}
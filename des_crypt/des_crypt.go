@@ -0,0 +1,118 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package des_crypt implements the traditional DES-based crypt(3) function of
+// Version 7 Unix, still found in old NIS maps and embedded systems.
+//
+// A hashed key is made of 13 characters, a salt of two characters followed by
+// the hash, and has no magic prefix. Only the first 8 characters of the key are
+// significant, and only the 7 low bits of each of them: this scheme should only
+// be used to verify legacy hashes.
+package des_crypt
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/internal/des"
+)
+
+func init() {
+	crypt.RegisterCryptMatcher(crypt.DES, New, isHash)
+}
+
+const (
+	SaltLenMin    = 2
+	SaltLenMax    = 2
+	RoundsDefault = 25
+	KeyLenMax     = 8
+	HashLen       = SaltLenMax + 11
+)
+
+const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// isHash reports whether hashedKey has the shape of a DES-based hashed key:
+// the last character carries the 4 remaining bits of the 64-bit hash, so its
+// 2 low bits are unused and always zero.
+func isHash(hashedKey string) bool {
+	if len(hashedKey) != HashLen {
+		return false
+	}
+	for i := 0; i < len(hashedKey); i++ {
+		if strings.IndexByte(itoa64, hashedKey[i]) < 0 {
+			return false
+		}
+	}
+	return strings.IndexByte(itoa64, hashedKey[HashLen-1])&3 == 0
+}
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the traditional DES-based crypt.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+// Generate hashes the key with the first two characters of the salt; the rest
+// of the salt, such as the hash of a hashed key, is ignored.
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		salt = c.Salt.Generate(SaltLenMax)
+	}
+	if len(salt) < SaltLenMin {
+		return "", common.ErrSaltFormat
+	}
+	salt = salt[:SaltLenMin]
+
+	saltBits, err := common.DecodeBase64_24BitUint(salt)
+	if err != nil {
+		return "", common.ErrSaltFormat
+	}
+
+	// As in crypt(3), the key ends at the first NUL byte.
+	if i := bytes.IndexByte(key, 0); i >= 0 {
+		key = key[:i]
+	}
+
+	keyBuf := make([]byte, KeyLenMax)
+	for i := 0; i < len(key) && i < KeyLenMax; i++ {
+		keyBuf[i] = key[i] << 1
+	}
+	sum := make([]byte, 8)
+	des.NewCipher(keyBuf).Encrypt(sum, sum, saltBits, RoundsDefault)
+	internal.CleanSensitiveData(keyBuf)
+
+	out := make([]byte, 0, HashLen)
+	out = append(out, salt...)
+	out = append(out, des.EncodeBlock(sum)...)
+	return string(out), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	if len(hashedKey) != HashLen {
+		return common.ErrSaltFormat
+	}
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+func (c *crypter) Cost(hashedKey string) (int, error) { return RoundsDefault, nil }
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
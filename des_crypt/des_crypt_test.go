@@ -0,0 +1,96 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package des_crypt
+
+import (
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var desCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+	}{
+		{
+			[]byte("ab"),
+			[]byte("password"),
+			"abJnggxhB/yWI",
+		},
+		{
+			[]byte("./"),
+			[]byte("test"),
+			"./H7.I.sVn7zo",
+		},
+		{
+			[]byte("zzRtj6pNdfpLE"),
+			[]byte("12345678extra"),
+			"zzRtj6pNdfpLE",
+		},
+		{
+			[]byte("Ab"),
+			[]byte("\xff\xa3X"),
+			"AbxV1m1mccK5o",
+		},
+		{
+			[]byte("ab"),
+			[]byte(""),
+			"abmF1QH4PEr.E",
+		},
+		{
+			[]byte("ab"),
+			[]byte("password\x00ignored"),
+			"abJnggxhB/yWI",
+		},
+	}
+
+	for i, d := range data {
+		hash, err := desCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	for i, d := range []string{"a", "a!", "$1"} {
+		if _, err := desCrypt.Generate([]byte("x"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	data := [][]byte{
+		[]byte("password"),
+		[]byte("12345"),
+		[]byte("That's amazing! I've got the same combination on my luggage!"),
+		[]byte("And change the combination on my luggage!"),
+		[]byte("         random  spa  c    ing."),
+		[]byte("94ajflkvjzpe8u3&*j1k513KLJ&*()"),
+	}
+	for i, d := range data {
+		hash, err := desCrypt.Generate(d, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hash) != HashLen || !isHash(hash) {
+			t.Errorf("Test %d failed: unexpected hash %s", i, hash)
+		}
+		if err = desCrypt.Verify(hash, d); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err = desCrypt.Verify(hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+}
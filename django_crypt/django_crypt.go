@@ -0,0 +1,327 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package django_crypt implements the built-in password hashers of Django. A
+// hashed key is made of the name of the algorithm, a "$", and fields specific
+// to the algorithm separated by "$":
+//
+//	pbkdf2_sha256$600000$seasalt$OAXyhAQ/4ZDA9V5RMExt3C1OwQdUpLZ99vm1McFlLRA=
+//	pbkdf2_sha1$1000$seasalt$ljleU4wBmTtz/MoG5YTwxpM0d7I=
+//	argon2$argon2id$v=19$m=102400,t=2,p=8$c2Vhc2FsdA$hash
+//	bcrypt_sha256$$2b$12$abcdefghijklmnopqrstuuhash
+//	bcrypt$$2b$12$abcdefghijklmnopqrstuuhash
+//	scrypt$16384$seasalt$8$1$hash
+//	md5$seasalt$3f86d0d3d465b7b458c231bf3555c0e3
+//
+// Argon2 and bcrypt hashed keys wrap those of the argon2_crypt and
+// bcrypt_crypt packages. The bcrypt_sha256 hasher hashes the key with SHA-256
+// first, so that keys longer than 72 bytes are significant. scrypt parameters
+// which need more than 1 GiB of memory are rejected.
+//
+// The defaults used when no salt is given are those of Django 5.2.
+package django_crypt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"hash"
+	"strconv"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/argon2_crypt"
+	"github.com/GehirnInc/crypt/bcrypt_crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.DJANGO, New,
+		MagicPrefixPBKDF2SHA256, MagicPrefixPBKDF2SHA1, MagicPrefixArgon2,
+		MagicPrefixBcryptSHA256, MagicPrefixBcrypt, MagicPrefixScrypt,
+		MagicPrefixMD5)
+}
+
+const (
+	MagicPrefix             = MagicPrefixPBKDF2SHA256
+	MagicPrefixPBKDF2SHA256 = "pbkdf2_sha256$"
+	MagicPrefixPBKDF2SHA1   = "pbkdf2_sha1$"
+	MagicPrefixArgon2       = "argon2$"
+	MagicPrefixBcryptSHA256 = "bcrypt_sha256$"
+	MagicPrefixBcrypt       = "bcrypt$"
+	MagicPrefixScrypt       = "scrypt$"
+	MagicPrefixMD5          = "md5$"
+
+	SaltLenMin    = 1
+	SaltLenMax    = 22 // as Django generates
+	RoundsMin     = 1
+	RoundsMax     = 1<<31 - 1
+	RoundsDefault = 1000000 // PBKDF2 iterations
+
+	BcryptRoundsDefault = 12
+	ScryptNDefault      = 1 << 14
+	ScryptRDefault      = 8
+	ScryptPDefault      = 1
+	ScryptHashLen       = 64
+)
+
+// Argon2Params are the Argon2 parameters used when no salt is given.
+var Argon2Params = argon2_crypt.Params{Memory: 102400, Time: 2, Threads: 8}
+
+const saltAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the Django password hashing. The
+// magic prefix of its salt selects the hasher used when no salt is given.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = c.generateSalt(); err != nil {
+			return "", err
+		}
+	}
+
+	switch {
+	case bytes.HasPrefix(salt, []byte(MagicPrefixPBKDF2SHA256)):
+		return c.generatePBKDF2(key, salt, MagicPrefixPBKDF2SHA256, sha256.New)
+	case bytes.HasPrefix(salt, []byte(MagicPrefixPBKDF2SHA1)):
+		return c.generatePBKDF2(key, salt, MagicPrefixPBKDF2SHA1, sha1.New)
+	case bytes.HasPrefix(salt, []byte(MagicPrefixArgon2)):
+		return generateWrapped(argon2_crypt.New(), key, salt, "argon2")
+	case bytes.HasPrefix(salt, []byte(MagicPrefixBcryptSHA256)):
+		sum := sha256.Sum256(key)
+		key = []byte(hex.EncodeToString(sum[:]))
+		defer internal.CleanSensitiveData(key)
+		return generateWrapped(bcrypt_crypt.New(), key, salt, MagicPrefixBcryptSHA256)
+	case bytes.HasPrefix(salt, []byte(MagicPrefixBcrypt)):
+		return generateWrapped(bcrypt_crypt.New(), key, salt, MagicPrefixBcrypt)
+	case bytes.HasPrefix(salt, []byte(MagicPrefixScrypt)):
+		return c.generateScrypt(key, salt)
+	case bytes.HasPrefix(salt, []byte(MagicPrefixMD5)):
+		return c.generateMD5(key, salt)
+	}
+	return "", common.ErrSaltPrefix
+}
+
+func (c *crypter) generatePBKDF2(key, salt []byte, prefix string, h func() hash.Hash) (string, error) {
+	fields := bytes.Split(salt[len(prefix):], []byte{'$'})
+	if len(fields) < 2 || len(fields) > 3 {
+		return "", common.ErrSaltFormat
+	}
+	rounds, err := c.parseRounds(fields[0])
+	if err != nil {
+		return "", err
+	}
+	if err = c.checkSalt(fields[1]); err != nil {
+		return "", err
+	}
+
+	size := h().Size()
+	sum := pbkdf2.Key(key, fields[1], rounds, size, h)
+
+	buf := bytes.Buffer{}
+	buf.WriteString(prefix)
+	buf.WriteString(strconv.Itoa(rounds))
+	buf.WriteByte('$')
+	buf.Write(fields[1])
+	buf.WriteByte('$')
+	buf.WriteString(pbkdf2.Base64.EncodeToString(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) generateScrypt(key, salt []byte) (string, error) {
+	fields := bytes.Split(salt[len(MagicPrefixScrypt):], []byte{'$'})
+	if len(fields) < 4 || len(fields) > 5 {
+		return "", common.ErrSaltFormat
+	}
+	N, ok1 := internal.ParseInt(fields[0])
+	r, ok2 := internal.ParseInt(fields[2])
+	p, ok3 := internal.ParseInt(fields[3])
+	if !ok1 || !ok2 || !ok3 || N <= 1 || N&(N-1) != 0 ||
+		!internal.ScryptParamsOK(uint64(N), uint64(r), uint64(p)) {
+		return "", common.ErrSaltRounds
+	}
+	if err := c.checkSalt(fields[1]); err != nil {
+		return "", err
+	}
+
+	sum, err := scrypt.Key(key, fields[1], N, r, p, ScryptHashLen)
+	if err != nil {
+		return "", common.ErrSaltRounds
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteString(MagicPrefixScrypt)
+	buf.WriteString(strconv.Itoa(N))
+	buf.WriteByte('$')
+	buf.Write(fields[1])
+	buf.WriteByte('$')
+	buf.WriteString(strconv.Itoa(r))
+	buf.WriteByte('$')
+	buf.WriteString(strconv.Itoa(p))
+	buf.WriteByte('$')
+	buf.WriteString(pbkdf2.Base64.EncodeToString(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) generateMD5(key, salt []byte) (string, error) {
+	fields := bytes.Split(salt[len(MagicPrefixMD5):], []byte{'$'})
+	if len(fields) > 2 {
+		return "", common.ErrSaltFormat
+	}
+	if err := c.checkSalt(fields[0]); err != nil {
+		return "", err
+	}
+
+	h := md5.New()
+	h.Write(fields[0])
+	h.Write(key)
+
+	buf := bytes.Buffer{}
+	buf.WriteString(MagicPrefixMD5)
+	buf.Write(fields[0])
+	buf.WriteByte('$')
+	buf.WriteString(hex.EncodeToString(h.Sum(nil)))
+	return buf.String(), nil
+}
+
+// generateWrapped hashes the key with the given Crypter, whose hashed keys are
+// stored after the given prefix.
+func generateWrapped(c crypt.Crypter, key, salt []byte, prefix string) (string, error) {
+	hashed, err := c.Generate(key, salt[len(prefix):])
+	if err != nil {
+		return "", err
+	}
+	return prefix + hashed, nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the number of iterations for PBKDF2 and Argon2, the base-2
+// logarithm of the number of rounds for bcrypt, N for scrypt, and 1 for MD5,
+// that is the values Django compares to its defaults.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	raw := []byte(hashedKey)
+	switch {
+	case bytes.HasPrefix(raw, []byte(MagicPrefixPBKDF2SHA256)),
+		bytes.HasPrefix(raw, []byte(MagicPrefixPBKDF2SHA1)):
+		fields := bytes.SplitN(raw, []byte{'$'}, 3)
+		if len(fields) < 3 {
+			return 0, common.ErrSaltFormat
+		}
+		return c.parseRounds(fields[1])
+	case bytes.HasPrefix(raw, []byte(MagicPrefixArgon2)):
+		return argon2_crypt.New().Cost(hashedKey[len("argon2"):])
+	case bytes.HasPrefix(raw, []byte(MagicPrefixBcryptSHA256)):
+		return bcrypt_crypt.New().Cost(hashedKey[len(MagicPrefixBcryptSHA256):])
+	case bytes.HasPrefix(raw, []byte(MagicPrefixBcrypt)):
+		return bcrypt_crypt.New().Cost(hashedKey[len(MagicPrefixBcrypt):])
+	case bytes.HasPrefix(raw, []byte(MagicPrefixScrypt)):
+		fields := bytes.SplitN(raw, []byte{'$'}, 3)
+		if len(fields) < 3 {
+			return 0, common.ErrSaltFormat
+		}
+		N, ok := internal.ParseInt(fields[1])
+		if !ok {
+			return 0, common.ErrSaltRounds
+		}
+		return N, nil
+	case bytes.HasPrefix(raw, []byte(MagicPrefixMD5)):
+		return 1, nil
+	}
+	return 0, common.ErrSaltPrefix
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// generateSalt returns a setting for the hasher of the magic prefix, with the
+// default parameters and a random salt.
+func (c *crypter) generateSalt() ([]byte, error) {
+	prefix := string(c.Salt.MagicPrefix)
+	salt := internal.RandomString(saltAlphabet, c.Salt.SaltLenMax)
+
+	buf := bytes.Buffer{}
+	switch prefix {
+	case MagicPrefixPBKDF2SHA256, MagicPrefixPBKDF2SHA1:
+		buf.WriteString(prefix)
+		buf.WriteString(strconv.Itoa(c.Salt.RoundsDefault))
+		buf.WriteByte('$')
+		buf.Write(salt)
+	case MagicPrefixArgon2:
+		setting, err := argon2_crypt.GenerateSalt(argon2_crypt.MagicPrefixID, Argon2Params)
+		if err != nil {
+			return nil, err
+		}
+		buf.WriteString("argon2")
+		buf.Write(setting)
+	case MagicPrefixBcryptSHA256, MagicPrefixBcrypt:
+		s := common.Salt{
+			MagicPrefix: []byte(bcrypt_crypt.MagicPrefix),
+			SaltLenMax:  bcrypt_crypt.SaltLenMax,
+			RoundsMin:   bcrypt_crypt.RoundsMin,
+			RoundsMax:   bcrypt_crypt.RoundsMax,
+		}
+		buf.WriteString(prefix)
+		buf.Write(s.GenerateWCost(BcryptRoundsDefault))
+	case MagicPrefixScrypt:
+		buf.WriteString(prefix)
+		buf.WriteString(strconv.Itoa(ScryptNDefault))
+		buf.WriteByte('$')
+		buf.Write(salt)
+		buf.WriteByte('$')
+		buf.WriteString(strconv.Itoa(ScryptRDefault))
+		buf.WriteByte('$')
+		buf.WriteString(strconv.Itoa(ScryptPDefault))
+	case MagicPrefixMD5:
+		buf.WriteString(prefix)
+		buf.Write(salt)
+	default:
+		return nil, common.ErrSaltPrefix
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *crypter) checkSalt(salt []byte) error {
+	if len(salt) < c.Salt.SaltLenMin {
+		return common.ErrSaltFormat
+	}
+	return nil
+}
+
+func (c *crypter) parseRounds(src []byte) (int, error) {
+	n, ok := internal.ParseInt(src)
+	if !ok || n < c.Salt.RoundsMin || n > c.Salt.RoundsMax {
+		return 0, common.ErrSaltRounds
+	}
+	return n, nil
+}
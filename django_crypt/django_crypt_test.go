@@ -0,0 +1,182 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package django_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+)
+
+var djangoCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("pbkdf2_sha256$600000$seasalt"),
+			[]byte("lètmein"),
+			"pbkdf2_sha256$600000$seasalt$OAXyhAQ/4ZDA9V5RMExt3C1OwQdUpLZ99vm1McFlLRA=",
+			600000,
+		},
+		{
+			[]byte("pbkdf2_sha256$1000$seasalt$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c="),
+			[]byte("password"),
+			"pbkdf2_sha256$1000$seasalt$YIWkt6M1JFXrHg5s0jZjBSc7C2Cz6QvchSJ0h8Y+i7c=",
+			1000,
+		},
+		{
+			[]byte("pbkdf2_sha1$1000$seasalt$"),
+			[]byte("lètmein"),
+			"pbkdf2_sha1$1000$seasalt$ljleU4wBmTtz/MoG5YTwxpM0d7I=",
+			1000,
+		},
+		{
+			[]byte("argon2$argon2id$v=19$m=256,t=2,p=2$c2Vhc2FsdHNlYXNhbHQ"),
+			[]byte("lètmein"),
+			"argon2$argon2id$v=19$m=256,t=2,p=2$c2Vhc2FsdHNlYXNhbHQ$SfYJ1eTiBQ/e+EIRRtSx5ye7QF2DaCQWOS4F4yaQCzA",
+			2,
+		},
+		{
+			[]byte("bcrypt_sha256$$2b$04$abcdefghijklmnopqrstuu"),
+			[]byte("lètmein"),
+			"bcrypt_sha256$$2b$04$abcdefghijklmnopqrstuulyx/yrfLnuLCi6gwFG5mrkJjuQKpL6S",
+			4,
+		},
+		{
+			[]byte("bcrypt$$2b$04$abcdefghijklmnopqrstuu"),
+			[]byte("lètmein"),
+			"bcrypt$$2b$04$abcdefghijklmnopqrstuuanVo7Xut1CH8VGIGlz1JovQh9GJbbtG",
+			4,
+		},
+		{
+			[]byte("scrypt$16384$seasalt$8$1"),
+			[]byte("lètmein"),
+			"scrypt$16384$seasalt$8$1$Qj3+9PPyRjSJIebHnG81TMjsqtaIGxNQG/aEB/NYafTJ7tibgfYz71m0ldQESkXFRkdVCBhhY8mx7rQwite/Pw==",
+			16384,
+		},
+		{
+			[]byte("scrypt$1024$seasalt$2$1$"),
+			[]byte("password"),
+			"scrypt$1024$seasalt$2$1$sAuVHNUGdYBsVdkD26nLyh9FfO2/vM2BEu5X8lX2GABAqlzXfcZuic1ASc1nRtulKdOZ0OjHXTiOFe86e9sSGw==",
+			1024,
+		},
+		{
+			[]byte("md5$seasalt$"),
+			[]byte("lètmein"),
+			"md5$seasalt$3f86d0d3d465b7b458c231bf3555c0e3",
+			1,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := djangoCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := djangoCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"pbkdf2_sha512$1000$seasalt",
+		"pbkdf2_sha256$1000",
+		"pbkdf2_sha256$01000$seasalt",
+		"pbkdf2_sha256$-1000$seasalt",
+		"pbkdf2_sha256$1000$$",
+		"pbkdf2_sha256$1000$sea$salt$hash",
+		"argon2$argon2x$v=19$m=256,t=2,p=2$c2Vhc2FsdHNlYXNhbHQ",
+		"bcrypt$$2b$04$abcdef",
+		"scrypt$1000$seasalt$8$1",
+		"scrypt$1024$seasalt$8",
+		"scrypt$1073741824$seasalt$8$1",
+		"scrypt$1048576$seasalt$8$2",
+		"md5$$",
+	}
+	for i, d := range data {
+		if _, err := djangoCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys encoded as Django's hashers do, computed with Python's
+	// hashlib, libxcrypt and golang.org/x/crypto/argon2.
+	data := []string{
+		"pbkdf2_sha256$1000$seasalt$+hs9qSCcGyNSGDNojIEbomuX9WI/mzTF5yfwAXqyKOo=",
+		"pbkdf2_sha1$1000$seasalt$479Zup/w5R6ptaWDmRzkiSxBZWQ=",
+		"argon2$argon2id$v=19$m=1024,t=2,p=2$c2Vhc2FsdHNlYXNhbHQxNg$noZlUmTmibH5QsCF7asoPRmpywnjoRhUFWUyQ6sQAPk",
+		"argon2$argon2i$v=19$m=512,t=3,p=1$c2Vhc2FsdHNlYXNhbHQxNg$b4NGPY84aIkkYHYAUUTttP4aidoDt12IC8z2I39nZsA",
+		"bcrypt_sha256$$2b$04$abcdefghijklmnopqrstuuraR8y8jUb0UMmCc9fwl/YxKZSpSs52u",
+		"bcrypt$$2b$04$abcdefghijklmnopqrstuu2r9OfJnfCsdneAXAGHnS4UpFFP8WIrW",
+		"scrypt$1024$seasalt$8$1$mBdsVxZCf6G+Bs1aXMjSdR6I0Vt8atpmM4Gvd0hAgnod0ZyMUPDbZ9Z0534FznWrdtrH3Ifd/FCn7Akjoe9Wrg==",
+		"md5$seasalt$51bd9678978c1b8d854cd8d771c933e8",
+	}
+	for i, d := range data {
+		if err := djangoCrypt.Verify(d, []byte("secret")); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err := djangoCrypt.Verify(d, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	data := []struct {
+		prefix string
+		want   string
+	}{
+		{MagicPrefixPBKDF2SHA256, "pbkdf2_sha256$1000000$"},
+		{MagicPrefixPBKDF2SHA1, "pbkdf2_sha1$1000000$"},
+		{MagicPrefixArgon2, "argon2$argon2id$v=19$m=102400,t=2,p=8$"},
+		{MagicPrefixBcryptSHA256, "bcrypt_sha256$$2b$12$"},
+		{MagicPrefixBcrypt, "bcrypt$$2b$12$"},
+		{MagicPrefixScrypt, "scrypt$16384$"},
+		{MagicPrefixMD5, "md5$"},
+	}
+	for i, d := range data {
+		c := &crypter{}
+		c.SetSalt(common.Salt{
+			MagicPrefix:   []byte(d.prefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		})
+		salt, err := c.generateSalt()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(salt), d.want) {
+			t.Errorf("Test %d failed\nExpected: %s..., got: %s", i, d.want, salt)
+		}
+	}
+
+	salt := internal.RandomString(saltAlphabet, SaltLenMax)
+	if len(salt) != SaltLenMax || strings.Trim(string(salt), saltAlphabet) != "" {
+		t.Errorf("Unexpected salt %s", salt)
+	}
+}
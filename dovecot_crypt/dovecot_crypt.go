@@ -0,0 +1,528 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package dovecot_crypt implements the password schemes of the Dovecot mail
+// server, whose passdb values are made of the scheme name in braces followed
+// by the password:
+//
+//	{SHA512-CRYPT}$6$saltsalt$qFmFH.bQmmtXzyBY0s9v7Oicd2z4XSIecDzlB5KiA2/jctKu9YterLp8wwnSq.qc.eoxqOmSuNp2xS0ktL3nh/
+//	{PLAIN-MD5}5f4dcc3b5aa765d61d8327deb882cf99
+//	{SSHA256}eje4XIkY6sGakInA+loqtNzj+QUo3N7sEIsj3fNge5lzYWx0
+//
+// The crypt(3) schemes, CRYPT, DES-CRYPT, MD5-CRYPT, SHA256-CRYPT,
+// SHA512-CRYPT, BLF-CRYPT, ARGON2I and ARGON2ID, are handled by the Crypters
+// of this library; as in Dovecot, MD5 is MD5-CRYPT, which also accepts
+// PLAIN-MD5 passwords. The binary schemes, PLAIN-MD4, PLAIN-MD5, LDAP-MD5,
+// SHA, SHA1, SHA256, SHA512, SMD5, SSHA, SSHA256, SSHA512, CRAM-MD5 (or
+// HMAC-MD5), DIGEST-MD5 and NTLM, are encoded in hexadecimal or in base64,
+// which a ".hex" or ".b64" suffix of the scheme name may override. Scheme
+// names are not case sensitive.
+//
+// The schemes shared with RFC 2307, CRYPT, MD5, SMD5, SHA, SSHA, SHA256,
+// SSHA256, SHA512 and SSHA512, are left to ldap_crypt by crypt.NewFromHash,
+// which verifies their Dovecot passwords the same way.
+//
+// DIGEST-MD5 passwords are salted with the user name, which must be given with
+// the WithUsername option. As the PLAIN, CLEAR and CLEARTEXT schemes store the
+// passwords as they are, they are refused unless the WithPlaintext option is
+// given.
+package dovecot_crypt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"hash"
+	"sort"
+	"strings"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/argon2_crypt"
+	"github.com/GehirnInc/crypt/bcrypt_crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/des_crypt"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/internal/md4"
+	_ "github.com/GehirnInc/crypt/ldap_crypt" // for the shared schemes
+	"github.com/GehirnInc/crypt/md5_crypt"
+	"github.com/GehirnInc/crypt/sha256_crypt"
+	"github.com/GehirnInc/crypt/sha512_crypt"
+)
+
+func init() {
+	// The schemes which ldap_crypt shares, such as {SSHA} or {CRYPT}, are
+	// registered by it, but for their suffixed forms, as it verifies the
+	// Dovecot passwords as well; it is imported so that they are supported
+	// whenever this package is.
+	var prefixes []string
+	for name, s := range cryptSchemes {
+		if !s.shared {
+			prefixes = append(prefixes, "{"+name+"}")
+		}
+	}
+	for name, s := range binarySchemes {
+		if !s.shared {
+			prefixes = append(prefixes, "{"+name+"}")
+		}
+		prefixes = append(prefixes, "{"+name+".")
+	}
+	sort.Strings(prefixes)
+	crypt.RegisterCrypt(crypt.DOVECOT, func() crypt.Crypter { return New() }, prefixes...)
+	// The prefixes are case sensitive, unlike the scheme names.
+	crypt.RegisterCryptMatcher(crypt.DOVECOT, func() crypt.Crypter { return New() }, isHash)
+}
+
+const (
+	MagicPrefix = "{SHA512-CRYPT}" // default scheme
+	SaltLenMin  = 1                // in bytes, for the salted binary schemes
+	SaltLenMax  = 4                // as Dovecot generates
+)
+
+var (
+	ErrPlaintextDisabled = errors.New("dovecot_crypt: plaintext schemes are disabled")
+	ErrUsernameRequired  = errors.New("dovecot_crypt: the user name is required")
+)
+
+const (
+	encodingNone = iota
+	encodingHex
+	encodingBase64
+)
+
+// cryptScheme is a scheme whose passwords are crypt(3) hashed keys.
+type cryptScheme struct {
+	new     func() crypt.Crypter
+	setting func() ([]byte, error) // of generated keys, nil for the default one
+	any     bool                   // whether any supported hashed key is accepted
+	shared  bool                   // whether ldap_crypt has the same scheme
+}
+
+var cryptSchemes = map[string]cryptScheme{
+	"CRYPT":        {new: des_crypt.New, any: true, shared: true},
+	"DES-CRYPT":    {new: des_crypt.New},
+	"MD5":          {new: md5_crypt.New, shared: true},
+	"MD5-CRYPT":    {new: md5_crypt.New},
+	"SHA256-CRYPT": {new: sha256_crypt.New},
+	"SHA512-CRYPT": {new: sha512_crypt.New},
+	"BLF-CRYPT":    {new: bcrypt_crypt.New},
+	"ARGON2I": {
+		new: argon2_crypt.New,
+		setting: func() ([]byte, error) {
+			return argon2_crypt.GenerateSalt(argon2_crypt.MagicPrefixI, argon2_crypt.DefaultParams)
+		},
+	},
+	"ARGON2ID": {new: argon2_crypt.New},
+}
+
+// binaryScheme is a scheme whose passwords are encoded in hexadecimal or in
+// base64.
+type binaryScheme struct {
+	encoding int
+	size     int // of the decoded password, zero if it varies
+	salted   bool
+	shared   bool // whether ldap_crypt has the same scheme
+	sum      func(c *crypter, key, salt []byte) ([]byte, error)
+}
+
+var binarySchemes = map[string]binaryScheme{
+	"PLAIN":      {encoding: encodingNone, sum: plainSum},
+	"CLEAR":      {encoding: encodingNone, sum: plainSum},
+	"CLEARTEXT":  {encoding: encodingNone, sum: plainSum},
+	"PLAIN-MD4":  {encoding: encodingHex, size: md4.Size, sum: digestSum(md4.New)},
+	"PLAIN-MD5":  {encoding: encodingHex, size: md5.Size, sum: digestSum(md5.New)},
+	"LDAP-MD5":   {encoding: encodingBase64, size: md5.Size, sum: digestSum(md5.New)},
+	"SHA":        {encoding: encodingBase64, size: sha1.Size, shared: true, sum: digestSum(sha1.New)},
+	"SHA1":       {encoding: encodingBase64, size: sha1.Size, sum: digestSum(sha1.New)},
+	"SHA256":     {encoding: encodingBase64, size: sha256.Size, shared: true, sum: digestSum(sha256.New)},
+	"SHA512":     {encoding: encodingBase64, size: sha512.Size, shared: true, sum: digestSum(sha512.New)},
+	"SMD5":       {encoding: encodingBase64, size: md5.Size, salted: true, shared: true, sum: digestSum(md5.New)},
+	"SSHA":       {encoding: encodingBase64, size: sha1.Size, salted: true, shared: true, sum: digestSum(sha1.New)},
+	"SSHA256":    {encoding: encodingBase64, size: sha256.Size, salted: true, shared: true, sum: digestSum(sha256.New)},
+	"SSHA512":    {encoding: encodingBase64, size: sha512.Size, salted: true, shared: true, sum: digestSum(sha512.New)},
+	"CRAM-MD5":   {encoding: encodingHex, size: 2 * md5.Size, sum: cramMD5Sum},
+	"HMAC-MD5":   {encoding: encodingHex, size: 2 * md5.Size, sum: cramMD5Sum},
+	"DIGEST-MD5": {encoding: encodingHex, size: md5.Size, sum: digestMD5Sum},
+	"NTLM":       {encoding: encodingHex, size: md4.Size, sum: ntlmSum},
+}
+
+// An Option sets a parameter of the crypt.Crypter returned by New.
+type Option func(*options)
+
+type options struct {
+	username       []byte
+	allowPlaintext bool
+}
+
+// WithUsername sets the name of the user whose password is hashed, which the
+// DIGEST-MD5 scheme needs. A name of the form "user@realm" is split as Dovecot
+// does.
+func WithUsername(username string) Option {
+	return func(o *options) { o.username = []byte(username) }
+}
+
+// WithPlaintext makes the crypt.Crypter accept the PLAIN, CLEAR and CLEARTEXT
+// schemes, which store the passwords as they are.
+func WithPlaintext() Option {
+	return func(o *options) { o.allowPlaintext = true }
+}
+
+type crypter struct {
+	Salt common.Salt
+	options
+}
+
+// New returns a new crypt.Crypter computing the Dovecot password schemes. The
+// magic prefix of its salt selects the scheme used when no salt is given.
+func New(opts ...Option) crypt.Crypter {
+	c := &crypter{
+		Salt: common.Salt{
+			MagicPrefix: []byte(MagicPrefix),
+			SaltLenMin:  SaltLenMin,
+			SaltLenMax:  SaltLenMax,
+		},
+	}
+	for _, opt := range opts {
+		opt(&c.options)
+	}
+	return c
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		salt = c.Salt.MagicPrefix
+	}
+	tag, rest, err := internal.SplitTag(salt)
+	if err != nil {
+		return "", err
+	}
+	name, suffix := splitSuffix(tag)
+
+	if s, ok := cryptSchemes[name]; ok && suffix == "" {
+		inner, err := c.newCrypter(s, rest)
+		if err != nil {
+			return "", err
+		}
+		if len(rest) == 0 && s.setting != nil {
+			if rest, err = s.setting(); err != nil {
+				return "", err
+			}
+		}
+		hashed, err := inner.Generate(key, rest)
+		if err != nil {
+			return "", err
+		}
+		return string(tag) + hashed, nil
+	}
+
+	s, enc, err := lookupBinary(name, suffix)
+	if err != nil {
+		return "", err
+	}
+	var saltBytes []byte
+	if s.salted {
+		if saltBytes, err = c.decodeSalt(s, enc, rest); err != nil {
+			return "", err
+		}
+	}
+	sum, err := c.sum(s, key, saltBytes)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	buf.Write(tag)
+	switch enc {
+	case encodingHex:
+		buf.WriteString(hex.EncodeToString(sum))
+	case encodingBase64:
+		buf.WriteString(base64.StdEncoding.EncodeToString(sum))
+	default:
+		buf.Write(sum)
+	}
+	return buf.String(), nil
+}
+
+// Verify checks the key against the decoded password, so that the encoding of
+// hashedKey does not matter. As Dovecot does, when the scheme name has no
+// suffix, a password whose length is twice the size of the decoded password
+// is read as hexadecimal, and any other one as base64.
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	tag, rest, err := internal.SplitTag([]byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	name, suffix := splitSuffix(tag)
+
+	if s, ok := cryptSchemes[name]; ok && suffix == "" {
+		if name == "MD5" && !bytes.HasPrefix(rest, []byte(md5_crypt.MagicPrefix)) {
+			name = "PLAIN-MD5"
+		} else {
+			inner, err := c.newCrypter(s, rest)
+			if err != nil {
+				return err
+			}
+			return inner.Verify(string(rest), key)
+		}
+	}
+
+	s, enc, err := lookupBinary(name, suffix)
+	if err != nil {
+		return err
+	}
+	if s.size != 0 && enc != encodingNone && suffix == "" {
+		if len(rest) == 2*s.size {
+			enc = encodingHex
+		} else {
+			enc = encodingBase64
+		}
+	}
+	stored, err := decodePassword(rest, enc, suffix != "")
+	if err != nil {
+		return err
+	}
+
+	var saltBytes []byte
+	if s.salted {
+		if len(stored) < s.size+c.Salt.SaltLenMin {
+			return common.ErrSaltFormat
+		}
+		saltBytes = stored[s.size:]
+	} else if s.size != 0 && len(stored) != s.size {
+		return common.ErrSaltFormat
+	}
+
+	sum, err := c.sum(s, key, saltBytes)
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(sum, stored) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the cost of the hashed key for the crypt(3) schemes, and 1 for
+// the others.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	tag, rest, err := internal.SplitTag([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	name, suffix := splitSuffix(tag)
+
+	if s, ok := cryptSchemes[name]; ok && suffix == "" {
+		if name == "MD5" && !bytes.HasPrefix(rest, []byte(md5_crypt.MagicPrefix)) {
+			return 1, nil
+		}
+		inner, err := c.newCrypter(s, rest)
+		if err != nil {
+			return 0, err
+		}
+		return inner.Cost(string(rest))
+	}
+	if _, _, err := lookupBinary(name, suffix); err != nil {
+		return 0, err
+	}
+	return 1, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// newCrypter returns the Crypter of the crypt(3) scheme s for the hashed key
+// or setting rest.
+func (c *crypter) newCrypter(s cryptScheme, rest []byte) (crypt.Crypter, error) {
+	if !s.any || len(rest) == 0 {
+		return s.new(), nil
+	}
+	// {CRYPT}{SHA512-CRYPT}... would recurse.
+	if rest[0] == '{' || !crypt.IsHashSupported(string(rest)) {
+		return nil, common.ErrSaltFormat
+	}
+	return crypt.NewFromHash(string(rest)), nil
+}
+
+// sum returns the password of the binary scheme s for the key, to which the
+// salt is appended.
+func (c *crypter) sum(s binaryScheme, key, salt []byte) ([]byte, error) {
+	sum, err := s.sum(c, key, salt)
+	if err != nil {
+		return nil, err
+	}
+	return append(sum, salt...), nil
+}
+
+// decodeSalt returns the salt of the setting rest for the salted scheme s. The
+// salt is appended to the digest in passwords, and is alone in settings; an
+// empty setting gets a random salt.
+func (c *crypter) decodeSalt(s binaryScheme, enc int, rest []byte) ([]byte, error) {
+	if len(rest) == 0 {
+		salt := make([]byte, c.Salt.SaltLenMax)
+		rand.Read(salt)
+		return salt, nil
+	}
+
+	raw, err := decodePassword(rest, enc, true)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) > s.size {
+		raw = raw[s.size:]
+	}
+	if len(raw) < c.Salt.SaltLenMin {
+		return nil, common.ErrSaltFormat
+	}
+	return raw, nil
+}
+
+// decodePassword decodes src with the encoding enc. A guessed hexadecimal
+// encoding falls back to base64, as some lengths are valid for both.
+func decodePassword(src []byte, enc int, explicit bool) ([]byte, error) {
+	switch enc {
+	case encodingHex:
+		dst, err := hex.DecodeString(string(src))
+		if err == nil {
+			return dst, nil
+		}
+		if explicit {
+			return nil, common.ErrSaltFormat
+		}
+		fallthrough
+	case encodingBase64:
+		dst, err := base64.StdEncoding.DecodeString(string(src))
+		if err != nil {
+			return nil, common.ErrSaltFormat
+		}
+		return dst, nil
+	default:
+		return src, nil
+	}
+}
+
+// splitSuffix returns the scheme name of tag in uppercase, and its encoding
+// suffix in lowercase.
+func splitSuffix(tag []byte) (name, suffix string) {
+	name = strings.ToUpper(string(tag[1 : len(tag)-1]))
+	if i := strings.IndexByte(name, '.'); i >= 0 {
+		name, suffix = name[:i], strings.ToLower(name[i+1:])
+	}
+	return name, suffix
+}
+
+// isHash reports whether hashedKey starts with a scheme of this package, in
+// any case. The schemes shared with ldap_crypt are left to it unless they have
+// an encoding suffix.
+func isHash(hashedKey string) bool {
+	tag, _, err := internal.SplitTag([]byte(hashedKey))
+	if err != nil {
+		return false
+	}
+	name, suffix := splitSuffix(tag)
+	if s, ok := cryptSchemes[name]; ok && suffix == "" {
+		return !s.shared
+	}
+	s, _, err := lookupBinary(name, suffix)
+	return err == nil && (!s.shared || suffix != "")
+}
+
+// lookupBinary returns the binary scheme of the given name, and the encoding
+// selected by the suffix or else the default one of the scheme.
+func lookupBinary(name, suffix string) (binaryScheme, int, error) {
+	s, ok := binarySchemes[name]
+	if !ok {
+		return s, 0, common.ErrSaltPrefix
+	}
+	switch suffix {
+	case "":
+		return s, s.encoding, nil
+	case "hex":
+		return s, encodingHex, nil
+	case "b64", "base64":
+		return s, encodingBase64, nil
+	}
+	return s, 0, common.ErrSaltPrefix
+}
+
+func plainSum(c *crypter, key, _ []byte) ([]byte, error) {
+	if !c.allowPlaintext {
+		return nil, ErrPlaintextDisabled
+	}
+	return append([]byte{}, key...), nil
+}
+
+func digestSum(h func() hash.Hash) func(c *crypter, key, salt []byte) ([]byte, error) {
+	return func(c *crypter, key, salt []byte) ([]byte, error) {
+		hh := h()
+		hh.Write(key)
+		hh.Write(salt)
+		return hh.Sum(nil), nil
+	}
+}
+
+// cramMD5Sum returns the states of MD5 after the outer and the inner padded
+// keys of HMAC-MD5, each as four little-endian words, from which CRAM-MD5
+// responses can be computed without the key.
+func cramMD5Sum(c *crypter, key, _ []byte) ([]byte, error) {
+	if len(key) > md5.BlockSize {
+		sum := md5.Sum(key)
+		key = sum[:]
+	}
+	sum := make([]byte, 0, 2*md5.Size)
+	for _, pad := range []byte{0x5c, 0x36} {
+		block := make([]byte, md5.BlockSize)
+		copy(block, key)
+		for i := range block {
+			block[i] ^= pad
+		}
+		h := md5.New()
+		h.Write(block)
+		// The state follows a 4-byte magic in big-endian words.
+		state, err := h.(encoding.BinaryMarshaler).MarshalBinary()
+		if err != nil {
+			return nil, err
+		}
+		for i := 0; i < 4; i++ {
+			sum = binary.LittleEndian.AppendUint32(sum, binary.BigEndian.Uint32(state[4+4*i:]))
+		}
+	}
+	return sum, nil
+}
+
+// digestMD5Sum returns the MD5 checksum of "user:realm:key", where the user
+// name is split at its last '@' into the user and the realm. This follows
+// digest_md5_generate in Dovecot's src/auth/password-scheme.c, which looks
+// for the realm with strrchr so that user names of the form
+// "user@domain@realm" keep their domain.
+func digestMD5Sum(c *crypter, key, _ []byte) ([]byte, error) {
+	if len(c.username) == 0 {
+		return nil, ErrUsernameRequired
+	}
+	user, realm := c.username, []byte{}
+	if i := bytes.LastIndexByte(user, '@'); i >= 0 {
+		user, realm = user[:i], user[i+1:]
+	}
+
+	h := md5.New()
+	h.Write(user)
+	h.Write([]byte{':'})
+	h.Write(realm)
+	h.Write([]byte{':'})
+	h.Write(key)
+	return h.Sum(nil), nil
+}
+
+// ntlmSum returns the MD4 checksum of the key encoded in UTF-16LE.
+func ntlmSum(c *crypter, key, _ []byte) ([]byte, error) {
+	h := md4.New()
+	h.Write(internal.UTF16LE(key))
+	return h.Sum(nil), nil
+}
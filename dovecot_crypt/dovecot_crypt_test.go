@@ -0,0 +1,394 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package dovecot_crypt
+
+import (
+	"crypto/hmac"
+	"crypto/md5"
+	"encoding"
+	"encoding/binary"
+	"encoding/hex"
+	"hash"
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+var dovecotCrypt = New(WithUsername("alice@example.com"), WithPlaintext())
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("{SHA512-CRYPT}$6$saltsalt"),
+			[]byte("password"),
+			"{SHA512-CRYPT}$6$saltsalt$qFmFH.bQmmtXzyBY0s9v7Oicd2z4XSIecDzlB5KiA2/jctKu9YterLp8wwnSq.qc.eoxqOmSuNp2xS0ktL3nh/",
+			5000,
+		},
+		{
+			[]byte("{CRYPT}$6$saltsalt"),
+			[]byte("password"),
+			"{CRYPT}$6$saltsalt$qFmFH.bQmmtXzyBY0s9v7Oicd2z4XSIecDzlB5KiA2/jctKu9YterLp8wwnSq.qc.eoxqOmSuNp2xS0ktL3nh/",
+			5000,
+		},
+		{
+			[]byte("{PLAIN-MD5}"),
+			[]byte("password"),
+			"{PLAIN-MD5}5f4dcc3b5aa765d61d8327deb882cf99",
+			1,
+		},
+		{
+			[]byte("{ldap-md5}"),
+			[]byte("password"),
+			"{ldap-md5}X03MO1qnZdYdgyfeuILPmQ==",
+			1,
+		},
+		{
+			[]byte("{SHA1}"),
+			[]byte("password"),
+			"{SHA1}W6ph5Mm5Pz8GgiULbPgzG37mj9g=",
+			1,
+		},
+		{
+			[]byte("{SSHA256}c2FsdA=="),
+			[]byte("password"),
+			"{SSHA256}eje4XIkY6sGakInA+loqtNzj+QUo3N7sEIsj3fNge5lzYWx0",
+			1,
+		},
+		{
+			[]byte("{SSHA256.hex}73616c74"),
+			[]byte("password"),
+			"{SSHA256.hex}7a37b85c8918eac19a9089c0fa5a2ab4dce3f90528dcdeec108b23ddf3607b9973616c74",
+			1,
+		},
+		{
+			[]byte("{CRAM-MD5}"),
+			[]byte("password"),
+			"{CRAM-MD5}9186d855e11eba527a7a52ca82b313e180d62234f0acc9051b527243d41e2740",
+			1,
+		},
+		{
+			[]byte("{DIGEST-MD5}"),
+			[]byte("password"),
+			"{DIGEST-MD5}c9706fd14542de2ba82140731fa07fad",
+			1,
+		},
+		{
+			[]byte("{NTLM}"),
+			[]byte("password"),
+			"{NTLM}8846f7eaee8fb117ad06bdd830b7586c",
+			1,
+		},
+		{
+			[]byte("{PLAIN-MD4.b64}"),
+			[]byte("password"),
+			"{PLAIN-MD4.b64}ip0JPxT4cB3xdzKyuxgsdA==",
+			1,
+		},
+		{
+			[]byte("{PLAIN}"),
+			[]byte("password"),
+			"{PLAIN}password",
+			1,
+		},
+		{
+			[]byte("{PLAIN.b64}"),
+			[]byte("password"),
+			"{PLAIN.b64}cGFzc3dvcmQ=",
+			1,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := dovecotCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := dovecotCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"SHA512-CRYPT}$6$saltsalt",
+		"{SHA512-CRYPT$6$saltsalt",
+		"{SHA512-CRYPT}$5$saltsalt",
+		"{SHA512-CRYPT.hex}$6$saltsalt",
+		"{CRYPT}{CRYPT}$6$saltsalt",
+		"{PBKDF2}",
+		"{SHA256.b32}",
+		"{SSHA256}c2Fsd",
+		"{SSHA256.hex}c2FsdA==",
+	}
+	for i, d := range data {
+		if _, err := dovecotCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestVerifyEncodings(t *testing.T) {
+	data := []string{
+		"{PLAIN-MD5}5f4dcc3b5aa765d61d8327deb882cf99",
+		"{PLAIN-MD5}5F4DCC3B5AA765D61D8327DEB882CF99",
+		"{PLAIN-MD5}X03MO1qnZdYdgyfeuILPmQ==",
+		"{PLAIN-MD5.b64}X03MO1qnZdYdgyfeuILPmQ==",
+		"{LDAP-MD5}5f4dcc3b5aa765d61d8327deb882cf99",
+		"{MD5}5f4dcc3b5aa765d61d8327deb882cf99",
+		"{MD5}$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/",
+		"{SHA1.hex}5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8",
+		"{SSHA256.HEX}7a37b85c8918eac19a9089c0fa5a2ab4dce3f90528dcdeec108b23ddf3607b9973616c74",
+		"{ssha256}eje4XIkY6sGakInA+loqtNzj+QUo3N7sEIsj3fNge5lzYWx0",
+		"{HMAC-MD5}9186d855e11eba527a7a52ca82b313e180d62234f0acc9051b527243d41e2740",
+		"{CLEARTEXT}password",
+	}
+	for i, d := range data {
+		if err := dovecotCrypt.Verify(d, []byte("password")); err != nil {
+			t.Errorf("Test %d failed: %s: %v", i, d, err)
+		}
+	}
+
+	for i, d := range []string{
+		"{PLAIN-MD5.b64}5f4dcc3b5aa765d61d8327deb882cf99",
+		"{PLAIN-MD5.hex}X03MO1qnZdYdgyfeuILPmQ==",
+		"{PLAIN-MD5}5f4dcc3b5aa765d61d8327deb882cf",
+		"{SSHA256}eje4XIkY6sGakInA+loqtNzj+QUo3N7sEIsj3fNge5k=",
+	} {
+		if err := dovecotCrypt.Verify(d, []byte("password")); err == nil || err == crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: %s was decoded", i, d)
+		}
+	}
+}
+
+func TestOptions(t *testing.T) {
+	c := New()
+	if _, err := c.Generate([]byte("password"), []byte("{DIGEST-MD5}")); err != ErrUsernameRequired {
+		t.Errorf("Expected: %v, got: %v", ErrUsernameRequired, err)
+	}
+	if err := c.Verify("{PLAIN}password", []byte("password")); err != ErrPlaintextDisabled {
+		t.Errorf("Expected: %v, got: %v", ErrPlaintextDisabled, err)
+	}
+	if err := c.Verify("{PLAIN-MD5}5f4dcc3b5aa765d61d8327deb882cf99", []byte("password")); err != nil {
+		t.Error(err)
+	}
+
+	// Without a realm, the realm is empty.
+	hash, err := New(WithUsername("alice")).Generate([]byte("password"), []byte("{DIGEST-MD5}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sum := md5.Sum([]byte("alice::password"))
+	if expected := "{DIGEST-MD5}" + hex.EncodeToString(sum[:]); hash != expected {
+		t.Errorf("Expected: %s, got: %s", expected, hash)
+	}
+
+	// The realm follows the last '@'.
+	hash, err = New(WithUsername("a@b@realm")).Generate([]byte("password"), []byte("{DIGEST-MD5}"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "{DIGEST-MD5}fe481f16f6133b1452de1bf735f883a8"; hash != expected {
+		t.Errorf("Expected: %s, got: %s", expected, hash)
+	}
+}
+
+// TestCRAMMD5 resumes HMAC-MD5 from the contexts of CRAM-MD5 passwords.
+func TestCRAMMD5(t *testing.T) {
+	keys := [][]byte{
+		[]byte("password"),
+		[]byte(strings.Repeat("long key ", 10)),
+	}
+	for i, key := range keys {
+		hash, err := dovecotCrypt.Generate(key, []byte("{CRAM-MD5}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		context, err := hex.DecodeString(hash[len("{CRAM-MD5}"):])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		challenge := []byte("<1896.697170952@postoffice.example.net>")
+		inner := resumeMD5(t, context[16:])
+		inner.Write(challenge)
+		outer := resumeMD5(t, context[:16])
+		outer.Write(inner.Sum(nil))
+
+		mac := hmac.New(md5.New, key)
+		mac.Write(challenge)
+		if !hmac.Equal(outer.Sum(nil), mac.Sum(nil)) {
+			t.Errorf("Test %d failed: %s", i, hash)
+		}
+	}
+}
+
+// resumeMD5 returns an MD5 hash which has consumed one block and has the given
+// state.
+func resumeMD5(t *testing.T, state []byte) hash.Hash {
+	marshaled := []byte("md5\x01")
+	for i := 0; i < 4; i++ {
+		marshaled = binary.BigEndian.AppendUint32(marshaled, binary.LittleEndian.Uint32(state[4*i:]))
+	}
+	marshaled = append(marshaled, make([]byte, md5.BlockSize)...)
+	marshaled = binary.BigEndian.AppendUint64(marshaled, md5.BlockSize)
+
+	h := md5.New()
+	if err := h.(encoding.BinaryUnmarshaler).UnmarshalBinary(marshaled); err != nil {
+		t.Fatal(err)
+	}
+	return h
+}
+
+func TestIsHashSupported(t *testing.T) {
+	for _, hash := range []string{
+		"{SHA512-CRYPT}$6$saltsalt$qFmFH.bQmmtXzyBY0s9v7Oicd2z4XSIecDzlB5KiA2/jctKu9YterLp8wwnSq.qc.eoxqOmSuNp2xS0ktL3nh/",
+		"{PLAIN-MD5}5f4dcc3b5aa765d61d8327deb882cf99",
+		"{SSHA256.hex}7a37b85c8918eac19a9089c0fa5a2ab4dce3f90528dcdeec108b23ddf3607b9973616c74",
+		"{CRAM-MD5}9186d855e11eba527a7a52ca82b313e180d62234f0acc9051b527243d41e2740",
+		"{sha512-crypt}$6$saltsalt$qFmFH.bQmmtXzyBY0s9v7Oicd2z4XSIecDzlB5KiA2/jctKu9YterLp8wwnSq.qc.eoxqOmSuNp2xS0ktL3nh/",
+		"{plain-md5}5f4dcc3b5aa765d61d8327deb882cf99",
+		"{Plain-MD5}5f4dcc3b5aa765d61d8327deb882cf99",
+		"{ssha256.HEX}7a37b85c8918eac19a9089c0fa5a2ab4dce3f90528dcdeec108b23ddf3607b9973616c74",
+		"{sha.hex}5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8",
+	} {
+		if !crypt.IsHashSupported(hash) {
+			t.Errorf("%s is not supported", hash)
+		}
+		if err := crypt.NewFromHash(hash).Verify(hash, []byte("password")); err != nil {
+			t.Errorf("%s: %v", hash, err)
+		}
+	}
+}
+
+func TestIsHashSupportedInvalid(t *testing.T) {
+	for _, hash := range []string{
+		"{sha512-crypt",
+		"{unknown}5f4dcc3b5aa765d61d8327deb882cf99",
+		"{plain-md5.xyz}5f4dcc3b5aa765d61d8327deb882cf99",
+	} {
+		if crypt.IsHashSupported(hash) {
+			t.Errorf("%s is supported", hash)
+		}
+	}
+}
+
+func TestNewFromHashShared(t *testing.T) {
+	hashes := []string{
+		"{CRYPT}$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/",
+		"{MD5}$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/",
+		"{MD5}5f4dcc3b5aa765d61d8327deb882cf99",
+	}
+	for _, tag := range []string{"{CRYPT}", "{MD5}", "{SMD5}", "{SHA}", "{SSHA}", "{SHA256}", "{SSHA256}", "{SHA512}", "{SSHA512}"} {
+		hash, err := dovecotCrypt.Generate([]byte("password"), []byte(tag))
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes = append(hashes, hash)
+	}
+	for _, tag := range []string{"{SHA}", "{SHA256}", "{SHA512}"} {
+		hash, err := dovecotCrypt.Generate([]byte("password"), []byte(tag[:len(tag)-1]+".HEX}"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		hashes = append(hashes, tag+hash[len(tag)+4:])
+	}
+
+	for _, hash := range hashes {
+		if !crypt.IsHashSupported(hash) {
+			t.Fatalf("%s is not supported", hash)
+		}
+		c := crypt.NewFromHash(hash)
+		if err := c.Verify(hash, []byte("password")); err != nil {
+			t.Errorf("%s: %v", hash, err)
+		}
+		if err := c.Verify(hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("%s: wrong key was accepted", hash)
+		}
+		if err := dovecotCrypt.Verify(hash, []byte("password")); err != nil {
+			t.Errorf("%s: %v", hash, err)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of the schemes of Dovecot, computed with libxcrypt,
+	// golang.org/x/crypto/argon2 and Python's hashlib, for the user name
+	// alice@example.com.
+	data := []struct {
+		hash string
+		key  []byte
+	}{
+		{"{SHA512-CRYPT}$6$dovecot1$VQ3p/.5yyXboYyllEbyvLwYrFyAHn3TC2WTxujdxbio/6AYRYf/Lgtg72RLLHw16HS40/SjKFS3ZlssNQfTlZ/", []byte("secret")},
+		{"{SHA512-CRYPT}$6$dovecot1$3HJF9.dioNB4doyCFO6ROdXhvC5/DwIa8z1JrBvzq.1q3RTvOZaYSMl1/Wdx14uYaux99D2ldIr7Hg9WIFWVB0", []byte("correct horse battery staple")},
+		{"{SHA256-CRYPT}$5$dovecot2$TyKPT1BDHRPXno0HimO4tmp3cbBVVC2rR6uYVXXd3u7", []byte("secret")},
+		{"{SHA256-CRYPT}$5$dovecot2$OmhDH2NQettc84NosvpO1OigJbSufo4rsuebRg8oBc7", []byte("correct horse battery staple")},
+		{"{MD5-CRYPT}$1$dovecot3$m/J.ZN0Evr4LEh5VRTRCn0", []byte("secret")},
+		{"{MD5-CRYPT}$1$dovecot3$x1TUK7OJSQGJ71oILZLne0", []byte("correct horse battery staple")},
+		{"{BLF-CRYPT}$2y$05$dovecotdovecotdovecotu/fTZH4Lu7kPQrSJU8GitsdovjLdH/dK", []byte("secret")},
+		{"{BLF-CRYPT}$2y$05$dovecotdovecotdovecotuJ0vOAaEnI8Sz7EGdlmKqlMJvdQHD4NG", []byte("correct horse battery staple")},
+		{"{ARGON2I}$argon2i$v=19$m=32768,t=4,p=1$YzJGc2RITmhiSFJ6WVd4MA$cRndXsmgrltegd4PDIa6GIsByL+t0tkK5btB9ir+oNs", []byte("secret")},
+		{"{ARGON2I}$argon2i$v=19$m=32768,t=4,p=1$YzJGc2RITmhiSFJ6WVd4MA$hQ6STCbi61JxX9M4itrVtwosdhhW6I7sgNfKdQcQ9rw", []byte("correct horse battery staple")},
+		{"{ARGON2ID}$argon2id$v=19$m=65536,t=3,p=1$MDEyMzQ1Njc4OWFiY2RlZg$iDjJp0vfyxy5j/Z0pBRNSMahvrjhBoaRI/gy7RpOWHc", []byte("secret")},
+		{"{ARGON2ID}$argon2id$v=19$m=65536,t=3,p=1$MDEyMzQ1Njc4OWFiY2RlZg$lMhvVBq9s9mqv+pZqgOWNUlIPpwLGnkzbna1TO5skX4", []byte("correct horse battery staple")},
+		{"{SSHA512}qPzZ0IyZURgekZeHydVK15rXkE+b0U/QrJIxUabn9PQd/6dS7P2K7UaCfMqtzzRyREKQT09phEV5XFANjmnKyIofPHcCntRR", []byte("secret")},
+		{"{SSHA512}lD7i/b6K2RENwGKr/uYxJKRv3EAevx7pW00DWwB6HByfNpRrE1bnInj47N1lmJDFsg5bF31lkncyY0klPZElQIofPHcCntRR", []byte("correct horse battery staple")},
+		{"{SMD5.hex}c500d60576bfa4a86af134085c24b6338a1f3c77", []byte("secret")},
+		{"{SMD5.hex}1e9e78c0ad0bb5aaf8a6d5eee6a7a3068a1f3c77", []byte("correct horse battery staple")},
+		{"{CRAM-MD5}cd3ba7deaad6e5ca23448ba42e379747a9e0f7f1fbc00c8a81bbaac395731b56", []byte("secret")},
+		{"{CRAM-MD5}09eae0fafdb8b11dde53f0031217a5c4938a03ccb3eebe1b64e0c243b569921c", []byte("correct horse battery staple")},
+		{"{DIGEST-MD5}b1726872c344b6dc8365b774f8fd6412", []byte("secret")},
+		{"{DIGEST-MD5}0aa957a5230b526246d640c747db7c5f", []byte("correct horse battery staple")},
+		{"{PLAIN}secret", []byte("secret")},
+		{"{PLAIN}correct horse battery staple", []byte("correct horse battery staple")},
+	}
+	for i, d := range data {
+		if err := dovecotCrypt.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := dovecotCrypt.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	schemes := []string{
+		"{SHA512-CRYPT}", "{SHA256-CRYPT}", "{MD5-CRYPT}", "{BLF-CRYPT}",
+		"{ARGON2I}", "{ARGON2ID}", "{SSHA512}", "{SMD5.hex}", "{CRAM-MD5}",
+		"{DIGEST-MD5}", "{PLAIN}",
+	}
+	for _, scheme := range schemes {
+		c := New(WithUsername("alice@example.com"), WithPlaintext())
+		c.SetSalt(common.Salt{
+			MagicPrefix: []byte(scheme),
+			SaltLenMin:  SaltLenMin,
+			SaltLenMax:  SaltLenMax,
+		})
+		hash, err := c.Generate([]byte("password"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(hash, scheme) {
+			t.Errorf("Unexpected hash %s", hash)
+		}
+		if err = c.Verify(hash, []byte("password")); err != nil {
+			t.Errorf("Verification failed: %s", hash)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package drupal_crypt implements the password hashing of Drupal 7, which
+// iterates SHA-512 as phpass does with MD5, and truncates the hashed key to
+// 55 characters:
+//
+//	$S$C33783772bRXEx1aCsvY.dqgaaSu76XmVlKrW9Qu8IQlvxHlmzLf
+//
+// The setting is made of the magic prefix, the base-2 logarithm of the number
+// of iterations in a single character, and a salt of 8 characters.
+//
+// Drupal 7 rehashes the unsalted MD5 hashes of Drupal 6 when it is upgraded,
+// and marks them with a "U" prefix. The key of such a hashed key is the
+// hexadecimal MD5 checksum of the password:
+//
+//	U$S$DsaltsaltwUZJPmieKbS5yEXIuMbHoS81BKBMexqWjbwzQLUxKqx
+package drupal_crypt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/hex"
+	"strings"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.DRUPAL, New, MagicPrefix, MagicPrefixLegacy)
+}
+
+const (
+	MagicPrefix       = "$S$"
+	MagicPrefixLegacy = "U$S$"
+	SaltLenMin        = 8
+	SaltLenMax        = 8
+	RoundsMin         = 7 // base-2 logarithm of the number of iterations
+	RoundsMax         = 30
+	RoundsDefault     = 15 // "D", as Drupal 7 generates
+	KeyLenMax         = 512
+	HashLen           = 55
+)
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the Drupal 7 password hashing.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+// GenerateSalt returns a setting with the given base-2 logarithm of the number
+// of iterations and a random salt.
+func GenerateSalt(rounds int) ([]byte, error) {
+	return generateSalt([]byte(MagicPrefix), rounds)
+}
+
+func generateSalt(magicPrefix []byte, rounds int) ([]byte, error) {
+	if rounds < RoundsMin || rounds > RoundsMax {
+		return nil, common.ErrSaltRounds
+	}
+
+	salt := make([]byte, SaltLenMax*6/8)
+	rand.Read(salt)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(magicPrefix) + 1 + SaltLenMax)
+	buf.Write(magicPrefix)
+	buf.Write(common.Base64_24BitUint(uint32(rounds), 1))
+	buf.Write(common.Base64_24Bit(salt))
+	return buf.Bytes(), nil
+}
+
+// Generate returns the hashed key for the given setting. A setting with the
+// "U" prefix of rehashed Drupal 6 keys gives a hashed key of the same form.
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = generateSalt(c.Salt.MagicPrefix, c.Salt.RoundsDefault); err != nil {
+			return "", err
+		}
+	}
+
+	var legacy bool
+	if bytes.HasPrefix(salt, []byte(MagicPrefixLegacy)) {
+		legacy = true
+		salt = salt[len(MagicPrefixLegacy)-len(MagicPrefix):]
+
+		sum := md5.Sum(key)
+		key = []byte(hex.EncodeToString(sum[:]))
+		defer internal.CleanSensitiveData(key)
+	}
+
+	setting, rounds, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha512.New()
+	h.Write(setting[len(setting)-c.Salt.SaltLenMax:])
+	h.Write(key)
+	sum := h.Sum(nil)
+	for i := 0; i < 1<<uint(rounds); i++ {
+		h.Reset()
+		h.Write(sum)
+		h.Write(key)
+		sum = h.Sum(sum[:0])
+	}
+
+	buf := bytes.Buffer{}
+	buf.Grow(1 + len(setting) + 86)
+	if legacy {
+		buf.WriteByte(MagicPrefixLegacy[0])
+	}
+	buf.Write(setting)
+	buf.Write(common.Base64_24Bit(sum))
+	if legacy {
+		buf.Truncate(1 + HashLen)
+	} else {
+		buf.Truncate(HashLen)
+	}
+	return buf.String(), nil
+}
+
+// Verify compares a hashed key with its possible key equivalent. Keys longer
+// than KeyLenMax bytes never match, as Drupal rejects them, except for the
+// legacy hashed keys where the limit applies to the MD5 pre-hash.
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	if len(key) > KeyLenMax && !strings.HasPrefix(hashedKey, MagicPrefixLegacy) {
+		return crypt.ErrKeyMismatch
+	}
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the base-2 logarithm of the number of iterations.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	raw := []byte(hashedKey)
+	if bytes.HasPrefix(raw, []byte(MagicPrefixLegacy)) {
+		raw = raw[len(MagicPrefixLegacy)-len(MagicPrefix):]
+	}
+	_, rounds, err := c.decode(raw)
+	if err != nil {
+		return 0, err
+	}
+	return rounds, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decode returns the setting part of raw, which is reproduced in the output,
+// and the base-2 logarithm of the number of iterations.
+func (c *crypter) decode(raw []byte) (setting []byte, rounds int, err error) {
+	if !bytes.HasPrefix(raw, c.Salt.MagicPrefix) {
+		return nil, 0, common.ErrSaltPrefix
+	}
+	prefixLen := len(c.Salt.MagicPrefix)
+	if len(raw) < prefixLen+1+c.Salt.SaltLenMax {
+		return nil, 0, common.ErrSaltFormat
+	}
+
+	n, err := common.DecodeBase64_24BitUint(raw[prefixLen : prefixLen+1])
+	if err != nil || int(n) < c.Salt.RoundsMin || int(n) > c.Salt.RoundsMax {
+		return nil, 0, common.ErrSaltRounds
+	}
+	return raw[:prefixLen+1+c.Salt.SaltLenMax], int(n), nil
+}
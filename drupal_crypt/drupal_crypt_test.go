@@ -0,0 +1,131 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package drupal_crypt
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var drupalCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("$S$C33783772bRXEx1aCsvY.dqgaaSu76XmVlKrW9Qu8IQlvxHlmzLf"),
+			[]byte("hashcat"),
+			"$S$C33783772bRXEx1aCsvY.dqgaaSu76XmVlKrW9Qu8IQlvxHlmzLf",
+			14,
+		},
+		{
+			[]byte("$S$Dsaltsalt"),
+			[]byte("password"),
+			"$S$DsaltsaltO.fH9qMIXUY3UFtIDiLwV0lfggsuLwVjkjXBZ8hWZcO",
+			15,
+		},
+		{
+			[]byte("$S$5saltsalt"),
+			[]byte("password"),
+			"$S$5saltsaltr80nTZX21ZPJU.NESy4yXLO9juIim.9QAO6ac7FDpSS",
+			7,
+		},
+		{
+			[]byte("U$S$Dsaltsalt"),
+			[]byte("password"),
+			"U$S$DsaltsaltwUZJPmieKbS5yEXIuMbHoS81BKBMexqWjbwzQLUxKqx",
+			15,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := drupalCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := drupalCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"$P$Dsaltsalt",
+		"U$P$Dsaltsalt",
+		"$S$Dsalt",
+		"$S$4saltsalt",
+		"$S$Tsaltsalt",
+	}
+	for i, d := range data {
+		if _, err := drupalCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of the hashcat examples and of Drupal's password.inc.
+	data := []struct {
+		hash string
+		key  []byte
+	}{
+		{"$S$C33783772bRXEx1aCsvY.dqgaaSu76XmVlKrW9Qu8IQlvxHlmzLf", []byte("hashcat")},
+		{"$S$DGyLnYdIj75KNmNoIR6E1EO45IFt/Wz0JJyhu1.xqq.tyquTasAm", []byte("drupal")},
+		{"$S$E2Bv9sX4qqvtTFH0X.Icn.zXWBR4MuvqfFTew99E7MbnWH3BJZxJ", []byte("correct horse battery staple")},
+		{"U$S$9aBcDeFgHETj4QerTVtOOe0fmTUIkAiUv1FonGHVeXhU//pACebf", []byte("admin")},
+	}
+	for i, d := range data {
+		if err := drupalCrypt.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := drupalCrypt.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	hash, err := drupalCrypt.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, MagicPrefix+"D") || len(hash) != HashLen {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = drupalCrypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verification failed: %s", hash)
+	}
+
+	long := bytes.Repeat([]byte("a"), KeyLenMax+1)
+	hash, err = drupalCrypt.Generate(long, []byte("$S$5saltsalt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = drupalCrypt.Verify(hash, long); err != crypt.ErrKeyMismatch {
+		t.Errorf("Overlong key was accepted")
+	}
+
+	hash, err = drupalCrypt.Generate(long, []byte("U$S$5saltsalt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = drupalCrypt.Verify(hash, long); err != nil {
+		t.Errorf("Overlong key was rejected for a legacy hash: %s", err)
+	}
+}
@@ -0,0 +1,14 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package crypt
+
+// Unregister removes the crypt function registered by the given name along
+// with its prefixes, so that the tests leave the registry as they found it.
+func Unregister(name string) {
+	registry.Lock()
+	defer registry.Unlock()
+	delete(registry.factories, name)
+	unregisterPrefixes(name)
+}
@@ -0,0 +1,109 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package gost_yescrypt implements the gost-yescrypt password hashing
+// algorithm of libxcrypt, which wraps yescrypt in HMACs based on the
+// GOST R 34.11-2012 (Streebog) hash function.
+//
+// The yescrypt output Y for a key K and a setting S is turned into:
+//
+//	HMAC_GOST256(HMAC_GOST256(GOST256(K), S), Y)
+//
+// where S is the setting without its trailing "$".
+package gost_yescrypt
+
+import (
+	"crypto/hmac"
+	"crypto/subtle"
+	"strings"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/internal/streebog"
+	"github.com/GehirnInc/crypt/yescrypt"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.GOST_YESCRYPT, New, MagicPrefix)
+}
+
+const (
+	MagicPrefix = "$gy$"
+	SaltLenMin  = yescrypt.SaltLenMin
+	SaltLenMax  = yescrypt.SaltLenMax
+)
+
+type crypter struct{ yescrypt crypt.Crypter }
+
+// New returns a new crypt.Crypter computing the gost-yescrypt password hashing.
+func New() crypt.Crypter {
+	c := &crypter{yescrypt.New()}
+	c.SetSalt(common.Salt{
+		MagicPrefix: []byte(MagicPrefix),
+		SaltLenMin:  SaltLenMin,
+		SaltLenMax:  SaltLenMax,
+	})
+	return c
+}
+
+// GenerateSalt returns a setting made of the magic prefix, the given yescrypt
+// parameters and a random salt.
+func GenerateSalt(params yescrypt.Params) ([]byte, error) {
+	salt, err := yescrypt.GenerateSalt(params)
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(MagicPrefix), salt[len(yescrypt.MagicPrefix):]...), nil
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	// The wrapped crypter parses the setting, and yields the yescrypt output
+	// after it.
+	out, err := c.yescrypt.Generate(key, salt)
+	if err != nil {
+		return "", err
+	}
+	i := strings.LastIndexByte(out, '$')
+	setting := out[:i]
+	y, err := common.DecodeBase64_24Bit([]byte(out[i+1:]))
+	if err != nil {
+		return "", err
+	}
+
+	h := streebog.New256()
+	h.Write(key)
+	hk := h.Sum(nil)
+
+	mac := hmac.New(streebog.New256, hk)
+	mac.Write([]byte(setting))
+	interm := mac.Sum(nil)
+	internal.CleanSensitiveData(hk)
+
+	mac = hmac.New(streebog.New256, interm)
+	mac.Write(y)
+	sum := mac.Sum(nil)
+	internal.CleanSensitiveData(interm)
+	internal.CleanSensitiveData(y)
+
+	return setting + "$" + string(common.Base64_24Bit(sum)), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the base-2 logarithm of N, the yescrypt block count.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	return c.yescrypt.Cost(hashedKey)
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.yescrypt.SetSalt(salt) }
@@ -0,0 +1,107 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package gost_yescrypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/yescrypt"
+)
+
+var gostYescryptCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("$gy$j9T$salt"),
+			[]byte("password"),
+			"$gy$j9T$salt$PgocKOGaTJMXjydXrWtFR3UIC5tudLAOxq3WqnePgbB",
+			12,
+		},
+		{
+			[]byte("$gy$jC5$LdJMENpBABJJ3hIHjB1Bi."),
+			[]byte("Hello world!"),
+			"$gy$jC5$LdJMENpBABJJ3hIHjB1Bi.$EtmXfWNwB/rbzLPu73cXKssplxQHqQVZcQacnxAPM./",
+			15,
+		},
+		{
+			[]byte("$gy$j75$JF7Zwzl/$ZBLYWyTRX2g/BkOR5cJBRGwno2/cxH857wLlkMciN00"),
+			[]byte(""),
+			"$gy$j75$JF7Zwzl/$ZBLYWyTRX2g/BkOR5cJBRGwno2/cxH857wLlkMciN00",
+			10,
+		},
+		{
+			// p = 2
+			[]byte("$gy$j85..$saltSALT"),
+			[]byte("pass"),
+			"$gy$j85..$saltSALT$JNvUUMBPDw4xy6JgLwcvd4ojBYlyIq/fGIPDMYAc5.0",
+			11,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := gostYescryptCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := gostYescryptCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	salt, err := GenerateSalt(yescrypt.Params{Flags: yescrypt.FlagsDefault, N: 1024, R: 8, P: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(salt), "$gy$j75$") {
+		t.Errorf("Unexpected salt %s", salt)
+	}
+	if _, err = gostYescryptCrypt.Generate([]byte("password"), salt); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	data := [][]byte{
+		[]byte("password"),
+		[]byte("12345"),
+		[]byte("That's amazing! I've got the same combination on my luggage!"),
+		[]byte("And change the combination on my luggage!"),
+		[]byte("         random  spa  c    ing."),
+		[]byte("94ajflkvjzpe8u3&*j1k513KLJ&*()"),
+	}
+	for i, d := range data {
+		hash, err := gostYescryptCrypt.Generate(d, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(hash, MagicPrefix) {
+			t.Errorf("Test %d failed: unexpected prefix %s", i, hash)
+		}
+		if err = gostYescryptCrypt.Verify(hash, d); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err = gostYescryptCrypt.Verify(hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+}
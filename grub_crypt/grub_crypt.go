@@ -0,0 +1,163 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package grub_crypt implements the password hashing of the GRUB 2 bootloader,
+// as generated by grub-mkpasswd-pbkdf2, which is PBKDF2-HMAC-SHA512. The
+// number of iterations, the salt and the derived key are written in decimal
+// and uppercase hexadecimal:
+//
+//	grub.pbkdf2.sha512.10000.73616C7473616C74.<64-byte derived key>
+//
+// Salts and derived keys of any length are accepted, and their hexadecimal
+// digits may be of either case, as GRUB does.
+package grub_crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"strconv"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.GRUB, New, MagicPrefix)
+}
+
+const (
+	MagicPrefix   = "grub.pbkdf2.sha512."
+	SaltLenMin    = 1  // in bytes
+	SaltLenMax    = 64 // in bytes, for generated salts only
+	HashLen       = 64
+	RoundsMin     = 1
+	RoundsMax     = 1<<31 - 1
+	RoundsDefault = 10000
+)
+
+// GenerateSalt returns a setting with the given number of iterations and a
+// random salt of SaltLenMax bytes, as grub-mkpasswd-pbkdf2 generates.
+func GenerateSalt(rounds int) ([]byte, error) {
+	return generateSalt([]byte(MagicPrefix), rounds)
+}
+
+func generateSalt(magicPrefix []byte, rounds int) ([]byte, error) {
+	if rounds < RoundsMin || rounds > RoundsMax {
+		return nil, common.ErrSaltRounds
+	}
+
+	salt := make([]byte, SaltLenMax)
+	rand.Read(salt)
+
+	out := bytes.Buffer{}
+	out.Write(magicPrefix)
+	out.WriteString(strconv.Itoa(rounds))
+	out.WriteByte('.')
+	out.WriteString(pbkdf2.HexUpper.EncodeToString(salt))
+	return out.Bytes(), nil
+}
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the GRUB 2 PBKDF2 password
+// hashing.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = generateSalt(c.Salt.MagicPrefix, c.Salt.RoundsDefault); err != nil {
+			return "", err
+		}
+	}
+	h, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	sum := pbkdf2.SHA512(key, h.salt, h.rounds, h.hashLen)
+
+	out := bytes.Buffer{}
+	out.Grow(len(h.setting) + 1 + 2*h.hashLen)
+	out.Write(h.setting)
+	out.WriteByte('.')
+	out.WriteString(pbkdf2.HexUpper.EncodeToString(sum))
+	return out.String(), nil
+}
+
+// Verify compares the hexadecimal digits of the hashed keys regardless of
+// their case.
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare(bytes.ToUpper([]byte(newHash)), bytes.ToUpper([]byte(hashedKey))) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the number of PBKDF2 iterations.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	h, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return h.rounds, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+type decoded struct {
+	setting []byte // reproduced in the output
+	rounds  int
+	salt    []byte
+	hashLen int
+}
+
+// decode parses raw, "grub.pbkdf2.sha512.<iterations>.<salt>" which may be
+// followed by ".<derived key>".
+func (c *crypter) decode(raw []byte) (h decoded, err error) {
+	if !bytes.HasPrefix(raw, c.Salt.MagicPrefix) {
+		return h, common.ErrSaltPrefix
+	}
+	fields := bytes.Split(raw[len(c.Salt.MagicPrefix):], []byte("."))
+	if len(fields) != 2 && len(fields) != 3 {
+		return h, common.ErrSaltFormat
+	}
+
+	var ok bool
+	if h.rounds, ok = internal.ParseInt(fields[0]); !ok || h.rounds < c.Salt.RoundsMin || h.rounds > c.Salt.RoundsMax {
+		return h, common.ErrSaltRounds
+	}
+	if h.salt, err = pbkdf2.HexUpper.DecodeString(string(fields[1])); err != nil || len(h.salt) < c.Salt.SaltLenMin {
+		return h, common.ErrSaltFormat
+	}
+
+	h.hashLen = HashLen
+	h.setting = raw
+	if len(fields) == 3 {
+		if len(fields[2]) == 0 || len(fields[2])%2 != 0 {
+			return h, common.ErrSaltFormat
+		}
+		h.hashLen = len(fields[2]) / 2
+		h.setting = raw[:len(raw)-len(fields[2])-1]
+	}
+	return h, nil
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package grub_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+var grubCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("grub.pbkdf2.sha512.10000.000102030405060708090A0B0C0D0E0F101112131415161718191A1B1C1D1E1F202122232425262728292A2B2C2D2E2F303132333435363738393A3B3C3D3E3F"),
+			[]byte("password"),
+			"grub.pbkdf2.sha512.10000.000102030405060708090A0B0C0D0E0F101112131415161718191A1B1C1D1E1F202122232425262728292A2B2C2D2E2F303132333435363738393A3B3C3D3E3F.DE25072AD1C2279350AA009DE388C0072AFD49313679A3CE2C980BE1F1AFB6084E2FF4E0BF920D3E24902616F118C50CBC79A21C877C08A5FDE691F177769D7A",
+			10000,
+		},
+		{
+			[]byte("grub.pbkdf2.sha512.1000.73616C7473616C74.E8602E049D46798114C786417B6CD2518BEBC798E42D3D91A472B9130E189D7C"),
+			[]byte("hashcat"),
+			"grub.pbkdf2.sha512.1000.73616C7473616C74.E8602E049D46798114C786417B6CD2518BEBC798E42D3D91A472B9130E189D7C",
+			1000,
+		},
+		{
+			[]byte("grub.pbkdf2.sha512.1000.73616c7473616c74.e8602e049d46798114c786417b6cd2518bebc798e42d3d91a472b9130e189d7c"),
+			[]byte("hashcat"),
+			"grub.pbkdf2.sha512.1000.73616c7473616c74.E8602E049D46798114C786417B6CD2518BEBC798E42D3D91A472B9130E189D7C",
+			1000,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := grubCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := grubCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"grub.pbkdf2.sha256.10000.73616C7473616C74",
+		"grub.pbkdf2.sha512.10000",
+		"grub.pbkdf2.sha512.0.73616C7473616C74",
+		"grub.pbkdf2.sha512.010000.73616C7473616C74",
+		"grub.pbkdf2.sha512.10000.",
+		"grub.pbkdf2.sha512.10000.73616C7473616C7",
+		"grub.pbkdf2.sha512.10000.73616C7473616C7G",
+		"grub.pbkdf2.sha512.10000.73616C7473616C74.",
+		"grub.pbkdf2.sha512.10000.73616C7473616C74.ABC",
+		"grub.pbkdf2.sha512.10000.73616C7473616C74.AB.CD",
+	}
+	for i, d := range data {
+		if _, err := grubCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	salt, err := GenerateSalt(20000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(salt), MagicPrefix+"20000.") || len(salt) != len(MagicPrefix)+6+2*SaltLenMax {
+		t.Errorf("unexpected setting %s", salt)
+	}
+	if _, err := GenerateSalt(0); err != common.ErrSaltRounds {
+		t.Errorf("0 rounds were accepted")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of the hashcat example, and of grub-mkpasswd-pbkdf2's
+	// scheme computed with Python's hashlib.
+	data := []struct {
+		hash string
+		key  []byte
+	}{
+		{"grub.pbkdf2.sha512.1000.73616C7473616C74.E8602E049D46798114C786417B6CD2518BEBC798E42D3D91A472B9130E189D7C", []byte("hashcat")},
+		{"grub.pbkdf2.sha512.10000.A851A1CE1AF1465C2C2647A9B443440C1CFD89447EE02C5E0F6EA950341E9E4F82DDC9D0B4271075647D3FD8CE08D0774FA0DB3E8731E0EFD518C7B032380201.22976A9D4DAD77B9B5C109873513DB6E3FBB895BE5C313D9F64FC9EB66BB3D26DDAFD1813D79518C4F221316759BE8A6EAA701ECBDC459DD105401EF76C3203B", []byte("secret")},
+		{"grub.pbkdf2.sha512.10000.1AF16D949D1B8BFE9B161E088B382B9FB38E416193F142D42E7E6524775B2A5EFDE0E6CE2DFF82645CEAA48328C7A08CB23331BE7F5230EEACEA43EF832D5279.BBC06B4C9EB31F12C2570873AA14F8FD22E95000D227A20D3A87E92F927595E6766503CD52A51F877A6A87437490F53177ECE77F75025B54F6935368CE9BA4A0", []byte("correct horse battery staple")},
+	}
+	for i, d := range data {
+		if err := grubCrypt.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := grubCrypt.Verify(strings.ToLower(d.hash), d.key); err != nil {
+			t.Errorf("Test %d failed: %s in lowercase", i, d.key)
+		}
+		if err := grubCrypt.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	hash, err := grubCrypt.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, MagicPrefix+"10000.") || len(hash) != len(MagicPrefix)+6+2*SaltLenMax+1+2*HashLen {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = grubCrypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verification failed: %s", hash)
+	}
+}
@@ -0,0 +1,300 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package argon2 implements the Argon2 memory-hard function as specified in
+// RFC 9106, in its three variants and in both versions 0x10 and 0x13.
+//
+// golang.org/x/crypto/argon2 only provides Argon2i and Argon2id in version
+// 0x13, which is not enough to verify every hash found in the wild.
+package argon2
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+	"sync"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// Variants of Argon2, with the values used in the computation.
+const (
+	Argon2d  = 0
+	Argon2i  = 1
+	Argon2id = 2
+)
+
+// Versions of Argon2.
+const (
+	Version10 = 0x10
+	Version13 = 0x13
+)
+
+const (
+	blockWords = 128 // 1 KiB blocks of 64-bit words
+	syncPoints = 4
+)
+
+type block [blockWords]uint64
+
+// Key derives a key of keyLen bytes from the password and the salt. The memory
+// is given in KiB and is rounded down to a multiple of 4*threads, with a
+// minimum of 8*threads.
+func Key(variant, version int, password, salt []byte, time, memory, threads, keyLen uint32) []byte {
+	if memory < 2*syncPoints*threads {
+		memory = 2 * syncPoints * threads
+	}
+	h0 := initialHash(variant, version, password, salt, time, memory, threads, keyLen)
+
+	memory = memory / (syncPoints * threads) * (syncPoints * threads)
+	laneLen := memory / threads
+	B := make([]block, memory)
+
+	var buf [1024]byte
+	for lane := uint32(0); lane < threads; lane++ {
+		for i := uint32(0); i < 2; i++ {
+			binary.LittleEndian.PutUint32(h0[blake2b.Size:], i)
+			binary.LittleEndian.PutUint32(h0[blake2b.Size+4:], lane)
+			hashLong(buf[:], h0[:])
+			B[lane*laneLen+i].fromBytes(buf[:])
+		}
+	}
+
+	for pass := uint32(0); pass < time; pass++ {
+		for slice := uint32(0); slice < syncPoints; slice++ {
+			// Within a slice, lanes only reference blocks of the other lanes
+			// from the previous slices, so they are filled concurrently.
+			var wg sync.WaitGroup
+			for lane := uint32(0); lane < threads; lane++ {
+				wg.Add(1)
+				go func(lane uint32) {
+					fillSegment(B, variant, version, pass, slice, lane, time, memory, threads)
+					wg.Done()
+				}(lane)
+			}
+			wg.Wait()
+		}
+	}
+
+	final := B[laneLen-1]
+	for lane := uint32(1); lane < threads; lane++ {
+		for i, v := range B[lane*laneLen+laneLen-1] {
+			final[i] ^= v
+		}
+	}
+	final.toBytes(buf[:])
+
+	out := make([]byte, keyLen)
+	hashLong(out, buf[:])
+	return out
+}
+
+func initialHash(variant, version int, password, salt []byte, time, memory, threads, keyLen uint32) (h0 [blake2b.Size + 8]byte) {
+	h, _ := blake2b.New512(nil)
+	writeUint32 := func(v uint32) {
+		var b [4]byte
+		binary.LittleEndian.PutUint32(b[:], v)
+		h.Write(b[:])
+	}
+
+	writeUint32(threads)
+	writeUint32(keyLen)
+	writeUint32(memory)
+	writeUint32(time)
+	writeUint32(uint32(version))
+	writeUint32(uint32(variant))
+	writeUint32(uint32(len(password)))
+	h.Write(password)
+	writeUint32(uint32(len(salt)))
+	h.Write(salt)
+	writeUint32(0) // secret
+	writeUint32(0) // associated data
+	h.Sum(h0[:0])
+	return
+}
+
+// hashLong is the variable-length hash function H'.
+func hashLong(out, in []byte) {
+	var prefix [4]byte
+	binary.LittleEndian.PutUint32(prefix[:], uint32(len(out)))
+
+	newHash := func(size int) hash.Hash {
+		h, _ := blake2b.New(size, nil)
+		return h
+	}
+
+	if len(out) <= blake2b.Size {
+		h := newHash(len(out))
+		h.Write(prefix[:])
+		h.Write(in)
+		h.Sum(out[:0])
+		return
+	}
+
+	var v [blake2b.Size]byte
+	h := newHash(blake2b.Size)
+	h.Write(prefix[:])
+	h.Write(in)
+	h.Sum(v[:0])
+
+	for len(out) > blake2b.Size {
+		copy(out, v[:32])
+		out = out[32:]
+		h = newHash(blake2b.Size)
+		if len(out) <= blake2b.Size {
+			h = newHash(len(out))
+		}
+		h.Write(v[:])
+		h.Sum(v[:0])
+	}
+	copy(out, v[:])
+}
+
+func fillSegment(B []block, variant, version int, pass, slice, lane, time, memory, threads uint32) {
+	laneLen := memory / threads
+	segLen := laneLen / syncPoints
+
+	dataIndependent := variant == Argon2i ||
+		(variant == Argon2id && pass == 0 && slice < syncPoints/2)
+
+	var input, address, zero block
+	if dataIndependent {
+		input[0] = uint64(pass)
+		input[1] = uint64(lane)
+		input[2] = uint64(slice)
+		input[3] = uint64(memory)
+		input[4] = uint64(time)
+		input[5] = uint64(variant)
+	}
+	nextAddresses := func() {
+		input[6]++
+		compress(&address, &zero, &input, false)
+		compress(&address, &zero, &address, false)
+	}
+
+	index := uint32(0)
+	if pass == 0 && slice == 0 {
+		// The first two blocks of each lane are already filled.
+		index = 2
+		if dataIndependent {
+			nextAddresses()
+		}
+	}
+
+	cur := lane*laneLen + slice*segLen + index
+	for ; index < segLen; index, cur = index+1, cur+1 {
+		prev := cur - 1
+		if cur%laneLen == 0 {
+			prev = cur + laneLen - 1
+		}
+
+		var pseudoRand uint64
+		if dataIndependent {
+			if index%blockWords == 0 {
+				nextAddresses()
+			}
+			pseudoRand = address[index%blockWords]
+		} else {
+			pseudoRand = B[prev][0]
+		}
+
+		refLane := uint32(pseudoRand>>32) % threads
+		if pass == 0 && slice == 0 {
+			refLane = lane
+		}
+		ref := refLane*laneLen + refIndex(pseudoRand, pass, slice, index, laneLen, segLen, refLane == lane)
+
+		compress(&B[cur], &B[prev], &B[ref], pass > 0 && version == Version13)
+	}
+}
+
+// refIndex maps pseudoRand onto the blocks of the reference lane which may be
+// referenced, favouring the most recent ones.
+func refIndex(pseudoRand uint64, pass, slice, index, laneLen, segLen uint32, sameLane bool) uint32 {
+	var area, start uint32
+	if pass == 0 {
+		area = slice * segLen
+	} else {
+		area = laneLen - segLen
+		start = (slice + 1) % syncPoints * segLen
+	}
+	if sameLane {
+		area += index - 1
+	} else if index == 0 {
+		area--
+	}
+
+	x := pseudoRand & 0xffffffff
+	x = x * x >> 32
+	y := uint64(area) * x >> 32
+	return (start + area - 1 - uint32(y)) % laneLen
+}
+
+// compress computes G(x, y) into out, XORing the previous content of out to
+// the result if xor is set.
+func compress(out, x, y *block, xor bool) {
+	var r, q block
+	for i := range r {
+		r[i] = x[i] ^ y[i]
+	}
+	q = r
+
+	for i := 0; i < blockWords; i += 16 {
+		permute(&q[i], &q[i+1], &q[i+2], &q[i+3], &q[i+4], &q[i+5], &q[i+6], &q[i+7],
+			&q[i+8], &q[i+9], &q[i+10], &q[i+11], &q[i+12], &q[i+13], &q[i+14], &q[i+15])
+	}
+	for i := 0; i < 16; i += 2 {
+		permute(&q[i], &q[i+1], &q[i+16], &q[i+17], &q[i+32], &q[i+33], &q[i+48], &q[i+49],
+			&q[i+64], &q[i+65], &q[i+80], &q[i+81], &q[i+96], &q[i+97], &q[i+112], &q[i+113])
+	}
+
+	if xor {
+		for i := range out {
+			out[i] ^= r[i] ^ q[i]
+		}
+	} else {
+		for i := range out {
+			out[i] = r[i] ^ q[i]
+		}
+	}
+}
+
+// permute is the BlaMka round function P applied to sixteen words.
+func permute(v0, v1, v2, v3, v4, v5, v6, v7, v8, v9, v10, v11, v12, v13, v14, v15 *uint64) {
+	mix(v0, v4, v8, v12)
+	mix(v1, v5, v9, v13)
+	mix(v2, v6, v10, v14)
+	mix(v3, v7, v11, v15)
+	mix(v0, v5, v10, v15)
+	mix(v1, v6, v11, v12)
+	mix(v2, v7, v8, v13)
+	mix(v3, v4, v9, v14)
+}
+
+func mix(a, b, c, d *uint64) {
+	fBlaMka := func(x, y uint64) uint64 {
+		return x + y + 2*(x&0xffffffff)*(y&0xffffffff)
+	}
+
+	*a = fBlaMka(*a, *b)
+	*d = bits.RotateLeft64(*d^*a, -32)
+	*c = fBlaMka(*c, *d)
+	*b = bits.RotateLeft64(*b^*c, -24)
+	*a = fBlaMka(*a, *b)
+	*d = bits.RotateLeft64(*d^*a, -16)
+	*c = fBlaMka(*c, *d)
+	*b = bits.RotateLeft64(*b^*c, -63)
+}
+
+func (b *block) fromBytes(src []byte) {
+	for i := range b {
+		b[i] = binary.LittleEndian.Uint64(src[i*8:])
+	}
+}
+
+func (b *block) toBytes(dst []byte) {
+	for i, v := range b {
+		binary.LittleEndian.PutUint64(dst[i*8:], v)
+	}
+}
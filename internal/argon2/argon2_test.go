@@ -0,0 +1,30 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package argon2
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	data := []struct {
+		variant, version      int
+		time, memory, threads uint32
+		out                   string
+	}{
+		{Argon2i, Version13, 2, 65536, 1, "c1628832147d9720c5bd1cfd61367078729f6dfb6f8fea9ff98158e0d7816ed0"},
+		{Argon2i, Version10, 2, 65536, 1, "f6c4db4a54e2a370627aff3db6176b94a2a209a62c8e36152711802f7b30c694"},
+		{Argon2id, Version13, 2, 65536, 1, "09316115d5cf24ed5a15a31a3ba326e5cf32edc24702987c02b6566f61913cf7"},
+	}
+
+	for i, d := range data {
+		out := Key(d.variant, d.version, []byte("password"), []byte("somesalt"),
+			d.time, d.memory, d.threads, 32)
+		if hex.EncodeToString(out) != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %x", i, d.out, out)
+		}
+	}
+}
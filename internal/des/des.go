@@ -0,0 +1,228 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package des implements the variant of the Data Encryption Standard used by
+// the DES-based crypt(3) functions, where a salt perturbs the expansion
+// function and a block is encrypted many times in a row.
+//
+// crypto/des cannot be used as it provides no way to alter the expansion.
+package des
+
+import (
+	"encoding/binary"
+)
+
+// Initial permutation.
+var ip = [64]byte{
+	58, 50, 42, 34, 26, 18, 10, 2,
+	60, 52, 44, 36, 28, 20, 12, 4,
+	62, 54, 46, 38, 30, 22, 14, 6,
+	64, 56, 48, 40, 32, 24, 16, 8,
+	57, 49, 41, 33, 25, 17, 9, 1,
+	59, 51, 43, 35, 27, 19, 11, 3,
+	61, 53, 45, 37, 29, 21, 13, 5,
+	63, 55, 47, 39, 31, 23, 15, 7,
+}
+
+// Final permutation, the inverse of ip.
+var fp = [64]byte{
+	40, 8, 48, 16, 56, 24, 64, 32,
+	39, 7, 47, 15, 55, 23, 63, 31,
+	38, 6, 46, 14, 54, 22, 62, 30,
+	37, 5, 45, 13, 53, 21, 61, 29,
+	36, 4, 44, 12, 52, 20, 60, 28,
+	35, 3, 43, 11, 51, 19, 59, 27,
+	34, 2, 42, 10, 50, 18, 58, 26,
+	33, 1, 41, 9, 49, 17, 57, 25,
+}
+
+// Permuted choice 1, selecting 56 bits of the key.
+var pc1 = [56]byte{
+	57, 49, 41, 33, 25, 17, 9,
+	1, 58, 50, 42, 34, 26, 18,
+	10, 2, 59, 51, 43, 35, 27,
+	19, 11, 3, 60, 52, 44, 36,
+	63, 55, 47, 39, 31, 23, 15,
+	7, 62, 54, 46, 38, 30, 22,
+	14, 6, 61, 53, 45, 37, 29,
+	21, 13, 5, 28, 20, 12, 4,
+}
+
+// Permuted choice 2, selecting the 48 bits of a subkey.
+var pc2 = [48]byte{
+	14, 17, 11, 24, 1, 5,
+	3, 28, 15, 6, 21, 10,
+	23, 19, 12, 4, 26, 8,
+	16, 7, 27, 20, 13, 2,
+	41, 52, 31, 37, 47, 55,
+	30, 40, 51, 45, 33, 48,
+	44, 49, 39, 56, 34, 53,
+	46, 42, 50, 36, 29, 32,
+}
+
+var keyShifts = [16]uint{1, 1, 2, 2, 2, 2, 2, 2, 1, 2, 2, 2, 2, 2, 2, 1}
+
+var sBoxes = [8][64]byte{
+	{
+		14, 4, 13, 1, 2, 15, 11, 8, 3, 10, 6, 12, 5, 9, 0, 7,
+		0, 15, 7, 4, 14, 2, 13, 1, 10, 6, 12, 11, 9, 5, 3, 8,
+		4, 1, 14, 8, 13, 6, 2, 11, 15, 12, 9, 7, 3, 10, 5, 0,
+		15, 12, 8, 2, 4, 9, 1, 7, 5, 11, 3, 14, 10, 0, 6, 13,
+	},
+	{
+		15, 1, 8, 14, 6, 11, 3, 4, 9, 7, 2, 13, 12, 0, 5, 10,
+		3, 13, 4, 7, 15, 2, 8, 14, 12, 0, 1, 10, 6, 9, 11, 5,
+		0, 14, 7, 11, 10, 4, 13, 1, 5, 8, 12, 6, 9, 3, 2, 15,
+		13, 8, 10, 1, 3, 15, 4, 2, 11, 6, 7, 12, 0, 5, 14, 9,
+	},
+	{
+		10, 0, 9, 14, 6, 3, 15, 5, 1, 13, 12, 7, 11, 4, 2, 8,
+		13, 7, 0, 9, 3, 4, 6, 10, 2, 8, 5, 14, 12, 11, 15, 1,
+		13, 6, 4, 9, 8, 15, 3, 0, 11, 1, 2, 12, 5, 10, 14, 7,
+		1, 10, 13, 0, 6, 9, 8, 7, 4, 15, 14, 3, 11, 5, 2, 12,
+	},
+	{
+		7, 13, 14, 3, 0, 6, 9, 10, 1, 2, 8, 5, 11, 12, 4, 15,
+		13, 8, 11, 5, 6, 15, 0, 3, 4, 7, 2, 12, 1, 10, 14, 9,
+		10, 6, 9, 0, 12, 11, 7, 13, 15, 1, 3, 14, 5, 2, 8, 4,
+		3, 15, 0, 6, 10, 1, 13, 8, 9, 4, 5, 11, 12, 7, 2, 14,
+	},
+	{
+		2, 12, 4, 1, 7, 10, 11, 6, 8, 5, 3, 15, 13, 0, 14, 9,
+		14, 11, 2, 12, 4, 7, 13, 1, 5, 0, 15, 10, 3, 9, 8, 6,
+		4, 2, 1, 11, 10, 13, 7, 8, 15, 9, 12, 5, 6, 3, 0, 14,
+		11, 8, 12, 7, 1, 14, 2, 13, 6, 15, 0, 9, 10, 4, 5, 3,
+	},
+	{
+		12, 1, 10, 15, 9, 2, 6, 8, 0, 13, 3, 4, 14, 7, 5, 11,
+		10, 15, 4, 2, 7, 12, 9, 5, 6, 1, 13, 14, 0, 11, 3, 8,
+		9, 14, 15, 5, 2, 8, 12, 3, 7, 0, 4, 10, 1, 13, 11, 6,
+		4, 3, 2, 12, 9, 5, 15, 10, 11, 14, 1, 7, 6, 0, 8, 13,
+	},
+	{
+		4, 11, 2, 14, 15, 0, 8, 13, 3, 12, 9, 7, 5, 10, 6, 1,
+		13, 0, 11, 7, 4, 9, 1, 10, 14, 3, 5, 12, 2, 15, 8, 6,
+		1, 4, 11, 13, 12, 3, 7, 14, 10, 15, 6, 8, 0, 5, 9, 2,
+		6, 11, 13, 8, 1, 4, 10, 7, 9, 5, 0, 15, 14, 2, 3, 12,
+	},
+	{
+		13, 2, 8, 4, 6, 15, 11, 1, 10, 9, 3, 14, 5, 0, 12, 7,
+		1, 15, 13, 8, 10, 3, 7, 4, 12, 5, 6, 11, 0, 14, 9, 2,
+		7, 11, 4, 1, 9, 12, 14, 2, 0, 6, 10, 13, 15, 3, 5, 8,
+		2, 1, 14, 7, 4, 10, 8, 13, 15, 12, 9, 0, 3, 5, 6, 11,
+	},
+}
+
+// Permutation of the output of the S-boxes.
+var pBox = [32]byte{
+	16, 7, 20, 21, 29, 12, 28, 17,
+	1, 15, 23, 26, 5, 18, 31, 10,
+	2, 8, 24, 14, 32, 27, 3, 9,
+	19, 13, 30, 6, 22, 11, 4, 25,
+}
+
+// spBoxes combines each S-box with the permutation of its output, indexed by
+// the six input bits.
+var spBoxes [8][64]uint32
+
+func init() {
+	for i := range sBoxes {
+		for v := 0; v < 64; v++ {
+			row := v>>4&2 | v&1
+			col := v >> 1 & 0xf
+			s := uint64(sBoxes[i][row<<4|col]) << (28 - 4*uint(i))
+			spBoxes[i][v] = uint32(permute(s, 32, pBox[:]))
+		}
+	}
+}
+
+// permute returns the bits of src, which is n bits wide, selected by table.
+// Bits are numbered from 1, starting at the most significant one.
+func permute(src uint64, n uint, table []byte) uint64 {
+	var dst uint64
+	for _, pos := range table {
+		dst = dst<<1 | src>>(n-uint(pos))&1
+	}
+	return dst
+}
+
+// Cipher is a DES key schedule.
+type Cipher struct {
+	subkeys [16]uint64
+}
+
+// NewCipher returns the key schedule of a 64-bit key. The least significant
+// bit of each byte is ignored.
+func NewCipher(key []byte) *Cipher {
+	c := new(Cipher)
+	c.SetKey(key)
+	return c
+}
+
+// SetKey replaces the key schedule.
+func (c *Cipher) SetKey(key []byte) {
+	cd := permute(binary.BigEndian.Uint64(key), 64, pc1[:])
+	l, r := uint32(cd>>28), uint32(cd&0x0fffffff)
+	for i, shift := range keyShifts {
+		l = (l<<shift | l>>(28-shift)) & 0x0fffffff
+		r = (r<<shift | r>>(28-shift)) & 0x0fffffff
+		c.subkeys[i] = permute(uint64(l)<<28|uint64(r), 56, pc2[:])
+	}
+}
+
+// Encrypt encrypts the 64-bit block src count times in a row. For each bit i
+// set in the 24-bit salt, the bits i and i+24 of the output of the expansion
+// function are swapped.
+func (c *Cipher) Encrypt(dst, src []byte, salt uint32, count int) {
+	var mask uint64
+	for i := uint(0); i < 24; i++ {
+		mask |= uint64(salt>>i&1) << (23 - i)
+	}
+
+	lr := permute(binary.BigEndian.Uint64(src), 64, ip[:])
+	l, r := uint32(lr>>32), uint32(lr)
+	for ; count > 0; count-- {
+		for _, k := range c.subkeys {
+			l, r = r, l^feistel(r, k, mask)
+		}
+		l, r = r, l
+	}
+	binary.BigEndian.PutUint64(dst, permute(uint64(l)<<32|uint64(r), 64, fp[:]))
+}
+
+func feistel(r uint32, k, mask uint64) uint32 {
+	// The expansion function reads the half block in overlapping groups of
+	// six bits, wrapping around.
+	x := uint64(r&1)<<33 | uint64(r)<<1 | uint64(r>>31)
+	var e uint64
+	for i := uint(0); i < 8; i++ {
+		e = e<<6 | x>>(28-4*i)&0x3f
+	}
+
+	t := (e>>24 ^ e) & mask
+	e ^= t | t<<24
+	e ^= k
+
+	var f uint32
+	for i := uint(0); i < 8; i++ {
+		f |= spBoxes[i][e>>(42-6*i)&0x3f]
+	}
+	return f
+}
+
+const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// EncodeBlock encodes the 64-bit block src in the 11 characters used by the
+// DES-based crypt functions, most significant bits first.
+func EncodeBlock(src []byte) []byte {
+	v := binary.BigEndian.Uint64(src)
+
+	dst := make([]byte, 11)
+	for i := uint(0); i < 10; i++ {
+		dst[i] = itoa64[v>>(58-6*i)&0x3f]
+	}
+	// The last character holds the remaining 4 bits.
+	dst[10] = itoa64[v<<2&0x3f]
+	return dst
+}
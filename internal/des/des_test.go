@@ -0,0 +1,33 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package des
+
+import (
+	"bytes"
+	"crypto/des"
+	"testing"
+)
+
+func TestEncrypt(t *testing.T) {
+	key := []byte("\x13\x34\x57\x79\x9b\xbc\xdf\xf1")
+	src := []byte("\x01\x23\x45\x67\x89\xab\xcd\xef")
+
+	// Without salt, the cipher is the standard DES.
+	std, err := des.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := make([]byte, 8)
+	copy(expected, src)
+	for count := 1; count <= 3; count++ {
+		std.Encrypt(expected, expected)
+
+		out := make([]byte, 8)
+		NewCipher(key).Encrypt(out, src, 0, count)
+		if !bytes.Equal(out, expected) {
+			t.Errorf("Test %d failed\nExpected: %x, got: %x", count, expected, out)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package md4 implements the MD4 hash algorithm as defined in RFC 1320.
+//
+// MD4 is broken and must only be used to verify legacy hashes.
+package md4
+
+import (
+	"encoding/binary"
+	"hash"
+	"math/bits"
+)
+
+const (
+	BlockSize = 64
+	Size      = 16
+)
+
+type digest struct {
+	s    [4]uint32
+	buf  [BlockSize]byte
+	nbuf int
+	len  uint64
+}
+
+// New returns a new hash.Hash computing the MD4 checksum.
+func New() hash.Hash {
+	d := new(digest)
+	d.Reset()
+	return d
+}
+
+func (d *digest) Reset() {
+	d.s = [4]uint32{0x67452301, 0xefcdab89, 0x98badcfe, 0x10325476}
+	d.nbuf = 0
+	d.len = 0
+}
+
+func (d *digest) Size() int { return Size }
+
+func (d *digest) BlockSize() int { return BlockSize }
+
+func (d *digest) Write(p []byte) (int, error) {
+	n := len(p)
+	d.len += uint64(n)
+	if d.nbuf > 0 {
+		c := copy(d.buf[d.nbuf:], p)
+		d.nbuf += c
+		p = p[c:]
+		if d.nbuf < BlockSize {
+			return n, nil
+		}
+		d.block(d.buf[:])
+		d.nbuf = 0
+	}
+	for len(p) >= BlockSize {
+		d.block(p[:BlockSize])
+		p = p[BlockSize:]
+	}
+	d.nbuf = copy(d.buf[:], p)
+	return n, nil
+}
+
+func (d *digest) Sum(in []byte) []byte {
+	// Make a copy so that the caller can keep writing and summing.
+	d0 := *d
+
+	var pad [BlockSize + 8]byte
+	pad[0] = 0x80
+	n := BlockSize - int(d0.len%BlockSize)
+	if n < 9 {
+		n += BlockSize
+	}
+	binary.LittleEndian.PutUint64(pad[n-8:], d0.len<<3)
+	d0.Write(pad[:n])
+
+	var out [Size]byte
+	for i, v := range d0.s {
+		binary.LittleEndian.PutUint32(out[4*i:], v)
+	}
+	return append(in, out[:]...)
+}
+
+var (
+	shifts1 = [4]int{3, 7, 11, 19}
+	shifts2 = [4]int{3, 5, 9, 13}
+	shifts3 = [4]int{3, 9, 11, 15}
+
+	order2 = [16]int{0, 4, 8, 12, 1, 5, 9, 13, 2, 6, 10, 14, 3, 7, 11, 15}
+	order3 = [16]int{0, 8, 4, 12, 2, 10, 6, 14, 1, 9, 5, 13, 3, 11, 7, 15}
+)
+
+func (d *digest) block(p []byte) {
+	var x [16]uint32
+	for i := range x {
+		x[i] = binary.LittleEndian.Uint32(p[4*i:])
+	}
+	a, b, c, e := d.s[0], d.s[1], d.s[2], d.s[3]
+
+	for i := 0; i < 16; i++ {
+		f := b&c | ^b&e
+		a, b, c, e = e, bits.RotateLeft32(a+f+x[i], shifts1[i%4]), b, c
+	}
+	for i := 0; i < 16; i++ {
+		g := b&c | b&e | c&e
+		a, b, c, e = e, bits.RotateLeft32(a+g+x[order2[i]]+0x5a827999, shifts2[i%4]), b, c
+	}
+	for i := 0; i < 16; i++ {
+		h := b ^ c ^ e
+		a, b, c, e = e, bits.RotateLeft32(a+h+x[order3[i]]+0x6ed9eba1, shifts3[i%4]), b, c
+	}
+
+	d.s[0] += a
+	d.s[1] += b
+	d.s[2] += c
+	d.s[3] += e
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package md4
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+)
+
+func TestSum(t *testing.T) {
+	data := []struct {
+		in  string
+		out string
+	}{
+		// RFC 1320, appendix A.5.
+		{"", "31d6cfe0d16ae931b73c59d7e0c089c0"},
+		{"a", "bde52cb31de33e46245e05fbdbd6fb24"},
+		{"abc", "a448017aaf21d8525fc10ae87aa6729d"},
+		{"message digest", "d9130a8164549fe818874806e1c7014b"},
+		{"abcdefghijklmnopqrstuvwxyz", "d79e1c308aa5bbcdeea8ed63df412da9"},
+		{"ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789", "043f8582f241db351ce627e153e7f0e4"},
+		{strings.Repeat("1234567890", 8), "e33b4ddc9c38f2199c3e7b164fcc0536"},
+	}
+
+	for i, d := range data {
+		h := New()
+		// Writing in two parts exercises the buffering.
+		h.Write([]byte(d.in[:len(d.in)/3]))
+		h.Write([]byte(d.in[len(d.in)/3:]))
+		if out := hex.EncodeToString(h.Sum(nil)); out != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, out)
+		}
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package streebog
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+func TestStreebog(t *testing.T) {
+	m2, _ := hex.DecodeString("d1e520e2e5f2f0e82c20d1f2f0e8e1eee6e820e2edf3f6e82c20e2e5" +
+		"fef2fa20f120eceef0ff20f1f2f0e5ebe0ece820ede020f5f0e0e1f0fbff20efebfaeafb" +
+		"20c8e3eef0e5e2fb")
+
+	data := []struct {
+		in     []byte
+		sum256 string
+		sum512 string
+	}{
+		{
+			[]byte(""),
+			"3f539a213e97c802cc229d474c6aa32a825a360b2a933a949fd925208d9ce1bb",
+			"8e945da209aa869f0455928529bcae4679e9873ab707b55315f56ceb98bef0a7" +
+				"362f715528356ee83cda5f2aac4c6ad2ba3a715c1bcd81cb8e9f90bf4c1c1a8a",
+		},
+		{
+			// RFC 6986, example 1
+			[]byte("012345678901234567890123456789012345678901234567890123456789012"),
+			"9d151eefd8590b89daa6ba6cb74af9275dd051026bb149a452fd84e5e57b5500",
+			"1b54d01a4af5b9d5cc3d86d68d285462b19abc2475222f35c085122be4ba1ffa" +
+				"00ad30f8767b3a82384c6574f024c311e2a481332b08ef7f41797891c1646f48",
+		},
+		{
+			// RFC 6986, example 2
+			m2,
+			"9dd2fe4e90409e5da87f53976d7405b0c0cac628fc669a741d50063c557e8f50",
+			"1e88e62226bfca6f9994f1f2d51569e0daf8475a3b0fe61a5300eee46d961376" +
+				"035fe83549ada2b8620fcd7c496ce5b33f0cb9dddc2b6460143b03dabac9fb28",
+		},
+		{
+			bytes.Repeat([]byte("a"), 64),
+			"c2ce0969b6e468445ecfaed89f614178f89cc37ab59523528a58745007f33ab2",
+			"613852076ca11156cf7d00f4feef0d5e3198e638f8e20eb02da2f5f7dca5b62d" +
+				"d9fb88e22e825f727ed6f25e4145dc868d0ef41e3e451e34b780e5547ade0d43",
+		},
+		{
+			bytes.Repeat([]byte("b"), 200),
+			"a6e1bf7d6673158a7010c9308fac3526594c3d785dbbf834f2564d214cc6ddd6",
+			"ba1dc605ab6edb424158511d2851cfe5eec99e1b229cab328ff5a14fd081e4cd" +
+				"a8d9c54142575632ead23f5d91a3c3d5029bfc712775e31752a01f88ecc5ba8c",
+		},
+	}
+
+	for i, d := range data {
+		h := New256()
+		h.Write(d.in)
+		if sum := hex.EncodeToString(h.Sum(nil)); sum != d.sum256 {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.sum256, sum)
+		}
+
+		// Write in several chunks to exercise the buffering.
+		h = New512()
+		for j := 0; j < len(d.in); j += 7 {
+			end := j + 7
+			if end > len(d.in) {
+				end = len(d.in)
+			}
+			h.Write(d.in[j:end])
+		}
+		if sum := hex.EncodeToString(h.Sum(nil)); sum != d.sum512 {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.sum512, sum)
+		}
+	}
+}
@@ -3,6 +3,16 @@
 // that can be found in LICENSE file.
 package internal
 
+import (
+	"bytes"
+	"crypto/rand"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/GehirnInc/crypt/common"
+)
+
 const (
 	cleanBytesLen = 64
 )
@@ -39,3 +49,82 @@ func RepeatByteSequence(input []byte, length int) []byte {
 
 	return sequence
 }
+
+// RandomString returns n characters drawn uniformly from alphabet, which must
+// not have more than 256 characters.
+func RandomString(alphabet string, n int) []byte {
+	s := make([]byte, 0, n)
+	buf := make([]byte, n)
+	for len(s) < n {
+		rand.Read(buf)
+		for _, b := range buf {
+			// Discard the bytes which would bias the choice.
+			if int(b) < 256/len(alphabet)*len(alphabet) && len(s) < n {
+				s = append(s, alphabet[int(b)%len(alphabet)])
+			}
+		}
+	}
+	return s
+}
+
+// ParseInt parses a positive decimal number without sign nor leading zeros,
+// which fits in 31 bits.
+func ParseInt(src []byte) (int, bool) {
+	if len(src) == 0 || src[0] == '0' {
+		return 0, false
+	}
+	n, err := strconv.ParseUint(string(src), 10, 31)
+	if err != nil {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// ScryptMemoryMax bounds the memory, 128*N*r*p bytes, which the scrypt
+// parameters of a hashed key may ask for: 1 GiB. scrypt allocates it at once,
+// so that larger parameters would exhaust the memory of the process.
+const ScryptMemoryMax = 1 << 30
+
+// ScryptParamsOK reports whether the scrypt parameters are positive and fit
+// in ScryptMemoryMax.
+func ScryptParamsOK(N, r, p uint64) bool {
+	return N >= 1 && r >= 1 && p >= 1 && N <= ScryptMemoryMax/128/r/p
+}
+
+// UTF16LE encodes a key in UTF-16LE. Keys which are not valid UTF-8 are
+// widened instead.
+func UTF16LE(key []byte) []byte {
+	if !utf8.Valid(key) {
+		return Widen(key)
+	}
+
+	units := utf16.Encode([]rune(string(key)))
+	dst := make([]byte, 2*len(units))
+	for i, u := range units {
+		dst[2*i] = byte(u)
+		dst[2*i+1] = byte(u >> 8)
+	}
+	return dst
+}
+
+// Widen encodes each byte of a key in 16 bits, little-endian.
+func Widen(key []byte) []byte {
+	dst := make([]byte, 2*len(key))
+	for i, b := range key {
+		dst[2*i] = b
+	}
+	return dst
+}
+
+// SplitTag splits raw into the scheme in braces, as in RFC 2307 and Dovecot,
+// and the rest.
+func SplitTag(raw []byte) (tag, rest []byte, err error) {
+	if len(raw) == 0 || raw[0] != '{' {
+		return nil, nil, common.ErrSaltPrefix
+	}
+	i := bytes.IndexByte(raw, '}')
+	if i < 0 {
+		return nil, nil, common.ErrSaltPrefix
+	}
+	return raw[:i+1], raw[i+1:], nil
+}
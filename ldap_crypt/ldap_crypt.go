@@ -0,0 +1,261 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package ldap_crypt implements the userPassword schemes of RFC 2307 as found
+// in OpenLDAP and other directory servers. A hashed key is made of the scheme
+// in braces followed by the base64-encoded digest, to which the salt is
+// appended for the salted schemes:
+//
+//	{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=
+//	{SSHA}gVK8WC9YyFT1gMsQHTGCgT3sSv5zYWx0
+//
+// The supported schemes are {MD5}, {SMD5}, {SHA}, {SSHA}, {SHA256}, {SSHA256},
+// {SHA384}, {SSHA384}, {SHA512} and {SSHA512}; scheme names are not case
+// sensitive. A setting is either a hashed key, or a scheme followed by the
+// base64-encoded salt, if any.
+//
+// The {CRYPT} scheme wraps a hashed key of crypt(3):
+//
+//	{CRYPT}$6$saltsalt$TVLlQcbpFVof5W3Yz4DTP6gRstiNuHwwTt6GLc1E5n0U0aDe...
+//
+// Such keys are handled by the Crypter which crypt.NewFromHash returns for
+// them, so the package implementing it must be imported as well.
+//
+// Dovecot shares the {CRYPT}, {MD5}, {SMD5}, {SHA}, {SSHA}, {SHA256},
+// {SSHA256}, {SHA512} and {SSHA512} schemes, and its passwords are verified as
+// well: {MD5} may wrap an MD5-crypt hashed key as {CRYPT} does, and the digests
+// of the unsalted schemes may be in hexadecimal.
+package ldap_crypt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"hash"
+	"strings"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+)
+
+func init() {
+	prefixes := []string{MagicPrefixCrypt}
+	for _, s := range schemes {
+		prefixes = append(prefixes, s.tag)
+	}
+	crypt.RegisterCrypt(crypt.LDAP, New, prefixes...)
+	// The prefixes are case sensitive, unlike the scheme names.
+	crypt.RegisterCryptMatcher(crypt.LDAP, New, isHash)
+}
+
+const (
+	MagicPrefixCrypt = "{CRYPT}"
+	MagicPrefixMD5   = "{MD5}"
+	MagicPrefix      = "{SSHA512}" // default scheme
+	SaltLenMin       = 1           // in bytes
+	SaltLenMax       = 8
+)
+
+type scheme struct {
+	tag    string
+	hash   func() hash.Hash
+	salted bool
+}
+
+var schemes = []scheme{
+	{MagicPrefixMD5, md5.New, false},
+	{"{SMD5}", md5.New, true},
+	{"{SHA}", sha1.New, false},
+	{"{SSHA}", sha1.New, true},
+	{"{SHA256}", sha256.New, false},
+	{"{SSHA256}", sha256.New, true},
+	{"{SHA384}", sha512.New384, false},
+	{"{SSHA384}", sha512.New384, true},
+	{"{SHA512}", sha512.New, false},
+	{"{SSHA512}", sha512.New, true},
+}
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the RFC 2307 password hashing. It
+// generates hashed keys with the scheme of the magic prefix of its salt, and a
+// salt of SaltLenMax bytes.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix: []byte(MagicPrefix),
+			SaltLenMin:  SaltLenMin,
+			SaltLenMax:  SaltLenMax,
+		},
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		salt = c.Salt.MagicPrefix
+	}
+	tag, rest, err := internal.SplitTag(salt)
+	if err != nil {
+		return "", err
+	}
+	if isWrapped(tag, rest) {
+		inner, err := newCrypter(rest)
+		if err != nil {
+			return "", err
+		}
+		hashed, err := inner.Generate(key, rest)
+		if err != nil {
+			return "", err
+		}
+		return string(tag) + hashed, nil
+	}
+
+	s, ok := lookupScheme(tag)
+	if !ok {
+		return "", common.ErrSaltPrefix
+	}
+	var saltBytes []byte
+	if s.salted {
+		if saltBytes, err = c.decodeSalt(s, rest); err != nil {
+			return "", err
+		}
+	}
+
+	h := s.hash()
+	h.Write(key)
+	h.Write(saltBytes)
+	sum := h.Sum(nil)
+	sum = append(sum, saltBytes...)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(tag) + base64.StdEncoding.EncodedLen(len(sum)))
+	buf.Write(tag)
+	buf.WriteString(base64.StdEncoding.EncodeToString(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	tag, rest, err := internal.SplitTag([]byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if isWrapped(tag, rest) {
+		inner, err := newCrypter(rest)
+		if err != nil {
+			return err
+		}
+		return inner.Verify(string(rest), key)
+	}
+	if s, ok := lookupScheme(tag); ok && !s.salted && len(rest) == 2*s.hash().Size() {
+		// A hexadecimal digest of Dovecot, which base64 cannot be mistaken
+		// for as its length differs.
+		stored, err := hex.DecodeString(string(rest))
+		if err != nil {
+			return common.ErrSaltFormat
+		}
+		h := s.hash()
+		h.Write(key)
+		if subtle.ConstantTimeCompare(h.Sum(nil), stored) != 1 {
+			return crypt.ErrKeyMismatch
+		}
+		return nil
+	}
+
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the cost of the wrapped hashed key for the {CRYPT} scheme, and 1
+// for the others.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	tag, rest, err := internal.SplitTag([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	if isWrapped(tag, rest) {
+		inner, err := newCrypter(rest)
+		if err != nil {
+			return 0, err
+		}
+		return inner.Cost(string(rest))
+	}
+	if _, ok := lookupScheme(tag); !ok {
+		return 0, common.ErrSaltPrefix
+	}
+	return 1, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decodeSalt returns the salt of the setting rest for the scheme s. The salt is
+// appended to the digest in hashed keys, and is alone in settings; an empty
+// setting gets a random salt.
+func (c *crypter) decodeSalt(s scheme, rest []byte) ([]byte, error) {
+	if len(rest) == 0 {
+		salt := make([]byte, c.Salt.SaltLenMax)
+		rand.Read(salt)
+		return salt, nil
+	}
+
+	raw, err := base64.StdEncoding.DecodeString(string(rest))
+	if err != nil {
+		return nil, common.ErrSaltFormat
+	}
+	if size := s.hash().Size(); len(raw) > size {
+		raw = raw[size:]
+	}
+	if len(raw) < c.Salt.SaltLenMin {
+		return nil, common.ErrSaltFormat
+	}
+	return raw, nil
+}
+
+func lookupScheme(tag []byte) (scheme, bool) {
+	for _, s := range schemes {
+		if strings.EqualFold(string(tag), s.tag) {
+			return s, true
+		}
+	}
+	return scheme{}, false
+}
+
+// isHash reports whether hashedKey starts with a supported scheme, in any
+// case.
+func isHash(hashedKey string) bool {
+	tag, _, err := internal.SplitTag([]byte(hashedKey))
+	if err != nil {
+		return false
+	}
+	_, ok := lookupScheme(tag)
+	return ok || strings.EqualFold(string(tag), MagicPrefixCrypt)
+}
+
+// isWrapped reports whether rest is a hashed key of crypt(3), that is for the
+// {CRYPT} scheme, or an MD5-crypt one for the {MD5} scheme as in Dovecot.
+func isWrapped(tag, rest []byte) bool {
+	return strings.EqualFold(string(tag), MagicPrefixCrypt) ||
+		strings.EqualFold(string(tag), MagicPrefixMD5) && bytes.HasPrefix(rest, []byte("$1$"))
+}
+
+// newCrypter returns the Crypter handling the hashed key wrapped by {CRYPT}.
+func newCrypter(hashedKey []byte) (crypt.Crypter, error) {
+	// {CRYPT}{CRYPT}... would recurse.
+	if len(hashedKey) == 0 || hashedKey[0] == '{' || !crypt.IsHashSupported(string(hashedKey)) {
+		return nil, common.ErrSaltFormat
+	}
+	return crypt.NewFromHash(string(hashedKey)), nil
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package ldap_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	_ "github.com/GehirnInc/crypt/md5_crypt"
+	_ "github.com/GehirnInc/crypt/sha512_crypt"
+)
+
+var ldapCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("{SHA}"),
+			[]byte("secret"),
+			"{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=",
+			1,
+		},
+		{
+			[]byte("{MD5}"),
+			[]byte("secret"),
+			"{MD5}Xr4ilOzQ4PCOq3aQ0qbuaQ==",
+			1,
+		},
+		{
+			[]byte("{SSHA}gVK8WC9YyFT1gMsQHTGCgT3sSv5zYWx0"),
+			[]byte("secret"),
+			"{SSHA}gVK8WC9YyFT1gMsQHTGCgT3sSv5zYWx0",
+			1,
+		},
+		{
+			[]byte("{ssha}c2FsdA=="),
+			[]byte("secret"),
+			"{ssha}gVK8WC9YyFT1gMsQHTGCgT3sSv5zYWx0",
+			1,
+		},
+		{
+			[]byte("{SMD5}c2FsdHNhbHQ="),
+			[]byte("secret"),
+			"{SMD5}VAfQ6nCkaw9o3u+x706wnXNhbHRzYWx0",
+			1,
+		},
+		{
+			[]byte("{SHA256}"),
+			[]byte("secret"),
+			"{SHA256}K7gNU3sdo+OL0wNhqoVWhr3g6s1xYv72ol/pe/Unols=",
+			1,
+		},
+		{
+			[]byte("{SSHA256}MTIzNDU2Nzg="),
+			[]byte("secret"),
+			"{SSHA256}swTyciKL1jSrpuiILDonRJjkh3hIgn9GWA19lVbEJrMxMjM0NTY3OA==",
+			1,
+		},
+		{
+			[]byte("{SSHA384}YWJjZA=="),
+			[]byte("secret"),
+			"{SSHA384}BQtz3spdVXl2H2HxSPXTL05umTevdDzXepJCWVsZpjASc9pnPDnFwvfZ80tHws58YWJjZA==",
+			1,
+		},
+		{
+			[]byte("{SHA512}"),
+			[]byte("secret"),
+			"{SHA512}vSsar3708Jvp9Szi2NWZZ02Bqp1qRCFpbcTZPdBhnWgs5WtNZKnvCXdhztmeD2cmW192CF5bDufKRpayrW/isg==",
+			1,
+		},
+		{
+			[]byte("{SSHA512}c2FsdHNhbHQ="),
+			[]byte("secret"),
+			"{SSHA512}aCu7JRc+kLsuEmFs1zTY+AiP7DSGnjjG+dH28Dp+E5usqoAixeTPihKqZmkWal4mUfp63tqvCAkFV1LKTDFH6XNhbHRzYWx0",
+			1,
+		},
+		{
+			[]byte("{CRYPT}$6$saltsalt$"),
+			[]byte("secret"),
+			"{CRYPT}$6$saltsalt$TVLlQcbpFVof5W3Yz4DTP6gRstiNuHwwTt6GLc1E5n0U0aDehy0S5knV8wiOQSpT0Y77vwPZN.Pq.H91p5hVO1",
+			5000,
+		},
+		{
+			[]byte("{CRYPT}$1$saltsalt$"),
+			[]byte("secret"),
+			"{CRYPT}$1$saltsalt$9xy1btjgzLYfb7hivXtC//",
+			1000,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := ldapCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := ldapCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"SSHA}c2FsdA==",
+		"{SSHA",
+		"{SHA1}",
+		"{SSHA}c2FsdA",
+		"{CRYPT}",
+		"{CRYPT}$unknown$",
+		"{CRYPT}{CRYPT}$6$saltsalt$",
+	}
+	for i, d := range data {
+		if _, err := ldapCrypt.Generate([]byte("secret"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys as stored by slappasswd, computed with Python's hashlib and
+	// libxcrypt.
+	data := []struct {
+		hash string
+		key  []byte
+	}{
+		{"{SHA}W6ph5Mm5Pz8GgiULbPgzG37mj9g=", []byte("password")},
+		{"{MD5}X03MO1qnZdYdgyfeuILPmQ==", []byte("password")},
+		{"{SSHA}TTZ8czxOc8BhZv5n67LK15eb3oiMHaIE9xIbfg==", []byte("password")},
+		{"{SMD5}ADHPqWDkENwbIuRYTuxJs4wdogT3Eht+", []byte("password")},
+		{"{SSHA256}M9N5kQJ55fTk1K+XWfzuoFq27IbnR0r7sSfvclzoqKyMHaIE9xIbfg==", []byte("password")},
+		{"{SSHA512}nJk7VHRAHqo7mNFfUocaHr5jaiWD79ZYhyRxkUHxDqXer50XLBdC3g1TT4/LdVPY4t9g1ZOJ4SiavEhKB5Ch44wdogT3Eht+", []byte("password")},
+		{"{CRYPT}$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/", []byte("password")},
+	}
+	for i, d := range data {
+		if err := ldapCrypt.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.hash)
+		}
+		if err := ldapCrypt.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	hash, err := ldapCrypt.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, MagicPrefix) {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = ldapCrypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verification failed: %s", hash)
+	}
+}
+
+func TestNewFromHash(t *testing.T) {
+	data := []string{
+		"{SSHA}gVK8WC9YyFT1gMsQHTGCgT3sSv5zYWx0",
+		"{CRYPT}$6$saltsalt$TVLlQcbpFVof5W3Yz4DTP6gRstiNuHwwTt6GLc1E5n0U0aDehy0S5knV8wiOQSpT0Y77vwPZN.Pq.H91p5hVO1",
+		"{ssha}gVK8WC9YyFT1gMsQHTGCgT3sSv5zYWx0",
+		"{Crypt}$6$saltsalt$TVLlQcbpFVof5W3Yz4DTP6gRstiNuHwwTt6GLc1E5n0U0aDehy0S5knV8wiOQSpT0Y77vwPZN.Pq.H91p5hVO1",
+	}
+	for i, d := range data {
+		if !crypt.IsHashSupported(d) {
+			t.Fatalf("Test %d failed: %s is not supported", i, d)
+		}
+		if err := crypt.NewFromHash(d).Verify(d, []byte("secret")); err != nil {
+			t.Errorf("Test %d failed: %s", i, err)
+		}
+	}
+}
+
+func TestDovecotPasswords(t *testing.T) {
+	data := []string{
+		"{MD5}$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/",
+		"{md5}$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/",
+		"{MD5}5f4dcc3b5aa765d61d8327deb882cf99",
+		"{SHA}5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8",
+		"{SHA256}5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+		"{SHA256}5E884898DA28047151D0E56F8DC6292773603D0D6AABBDD62A11EF721D1542D8",
+		"{SHA512}b109f3bbbc244eb82441917ed06d618b9008dd09b3befd1b5e07394c706a8bb980b1d7785e5976ec049b46df5f1326af5a2ea6d103fd07c95385ffab0cacbc86",
+	}
+	for i, d := range data {
+		if !crypt.IsHashSupported(d) {
+			t.Fatalf("Test %d failed: %s is not supported", i, d)
+		}
+		c := crypt.NewFromHash(d)
+		if err := c.Verify(d, []byte("password")); err != nil {
+			t.Errorf("Test %d failed: %s", i, err)
+		}
+		if err := c.Verify(d, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	cost, err := ldapCrypt.Cost("{MD5}$1$saltsalt$qjXMvbEw8oaL.CzflDtaK/")
+	if err != nil || cost != 1000 {
+		t.Errorf("Unexpected cost %d, %v", cost, err)
+	}
+	if err = ldapCrypt.Verify("{SHA}5baa61e4c9b93f3f0682250b6cf8331b7ee68fdz", []byte("password")); err == nil {
+		t.Errorf("Invalid hexadecimal digest was accepted")
+	}
+}
+
+func TestSetSalt(t *testing.T) {
+	c := New()
+	c.SetSalt(common.Salt{MagicPrefix: []byte("{SSHA}"), SaltLenMin: SaltLenMin, SaltLenMax: 4})
+	hash, err := c.Generate([]byte("secret"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "{SSHA}") || len(hash) != len("{SSHA}")+32 {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+}
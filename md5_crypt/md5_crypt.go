@@ -20,7 +20,7 @@ func init() {
 	crypt.RegisterCrypt(crypt.MD5, New, MagicPrefix)
 }
 
-// NOTE: Cisco IOS only allows salts of length 4.
+// NOTE: Cisco IOS only allows salts of length 4, which cisco.NewType5 enforces.
 
 const (
 	MagicPrefix   = "$1$"
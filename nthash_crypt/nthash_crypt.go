@@ -0,0 +1,106 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package nthash_crypt implements the "$3$" crypt(3) function of FreeBSD,
+// which stores the NT hash used by Windows and Samba, the MD4 checksum of the
+// key encoded in UTF-16LE:
+//
+//	$3$$8846f7eaee8fb117ad06bdd830b7586c
+//
+// This scheme is legacy: it has neither salt nor rounds, and must only be used
+// to verify existing hashes.
+//
+// FreeBSD and libxcrypt widen each byte of the key to 16 bits instead of
+// decoding it as UTF-8, which only makes a difference for keys with non-ASCII
+// characters. Generate follows Windows, and Verify accepts both.
+package nthash_crypt
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/hex"
+	"unicode/utf8"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/internal/md4"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.NTHASH, New, MagicPrefix)
+}
+
+const (
+	MagicPrefix = "$3$"
+	SaltLenMin  = 0
+	SaltLenMax  = 0
+)
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the NT hash.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix: []byte(MagicPrefix),
+			SaltLenMin:  SaltLenMin,
+			SaltLenMax:  SaltLenMax,
+		},
+	}
+}
+
+// Generate returns the NT hash of the key. The salt, if any, must only have the
+// magic prefix; the rest of it is ignored.
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) != 0 && !bytes.HasPrefix(salt, c.Salt.MagicPrefix) {
+		return "", common.ErrSaltPrefix
+	}
+	return c.generate(internal.UTF16LE(key)), nil
+}
+
+func (c *crypter) generate(key []byte) string {
+	h := md4.New()
+	h.Write(key)
+	internal.CleanSensitiveData(key)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(c.Salt.MagicPrefix) + 1 + 2*md4.Size)
+	buf.Write(c.Salt.MagicPrefix)
+	buf.WriteByte('$')
+	buf.WriteString(hex.EncodeToString(h.Sum(nil)))
+	return buf.String()
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) == 1 {
+		return nil
+	}
+
+	// Hashes of non-ASCII keys made by crypt(3).
+	if !isASCII(key) {
+		newHash = c.generate(internal.Widen(key))
+		if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) == 1 {
+			return nil
+		}
+	}
+	return crypt.ErrKeyMismatch
+}
+
+func (c *crypter) Cost(hashedKey string) (int, error) { return 1, nil }
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+func isASCII(key []byte) bool {
+	for _, b := range key {
+		if b >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package nthash_crypt
+
+import (
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var nthashCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+	}{
+		{
+			[]byte("$3$"),
+			[]byte("password"),
+			"$3$$8846f7eaee8fb117ad06bdd830b7586c",
+		},
+		{
+			nil,
+			[]byte(""),
+			"$3$$31d6cfe0d16ae931b73c59d7e0c089c0",
+		},
+		{
+			[]byte("$3$$0000"),
+			[]byte("Hello world!"),
+			"$3$$87ee0af454a9cb8d90d24196068637a8",
+		},
+		{
+			[]byte("$3$"),
+			[]byte("\xc3\xa9"),
+			"$3$$e77286d072c7858e9110cc3a011d2ac8",
+		},
+	}
+
+	for i, d := range data {
+		hash, err := nthashCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+	}
+
+	if _, err := nthashCrypt.Generate([]byte("password"), []byte("$1$")); err == nil {
+		t.Errorf("Invalid prefix was accepted")
+	}
+}
+
+func TestVerifyWidened(t *testing.T) {
+	// As generated by libxcrypt, which widens each byte of the key.
+	hash := "$3$$08eb94a3771213775172fc988504a4c1"
+	if err := nthashCrypt.Verify(hash, []byte("\xc3\xa9")); err != nil {
+		t.Errorf("Verification failed: %s", err)
+	}
+	if err := nthashCrypt.Verify(hash, []byte("\xc3\xa8")); err != crypt.ErrKeyMismatch {
+		t.Errorf("Wrong key was accepted")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of libxcrypt.
+	data := []struct {
+		hash string
+		key  []byte
+	}{
+		{"$3$$8846f7eaee8fb117ad06bdd830b7586c", []byte("password")},
+		{"$3$$31d6cfe0d16ae931b73c59d7e0c089c0", []byte("")},
+		{"$3$$cc2c846fbf2013694591d0ec141c4928", []byte("U*U*U*U*")},
+		{"$3$$08d99d1c849800a7919753af1dea415d", []byte("\xc3\xa9t\xc3\xa9")},
+	}
+	for i, d := range data {
+		if err := nthashCrypt.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := nthashCrypt.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package passlib_crypt implements the modular crypt formats which the passlib
+// library of Python defines for PBKDF2 and for bcrypt of a SHA-256 digest:
+//
+//	$pbkdf2$131000$0ZrzXitFSGltTQnBWOsdAw$checksum
+//	$pbkdf2-sha256$6400$0ZrzXitFSGltTQnBWOsdAw$Y11AchqV4b0sUisdZd0Xr97KWoymNE0LNNrnEgY4H9M
+//	$pbkdf2-sha512$25000$0ZrzXitFSGltTQnBWOsdAw$checksum
+//	$bcrypt-sha256$v=2,t=2b,r=12$abcdefghijklmnopqrstuu$checksum
+//
+// The salt and the checksum of the PBKDF2 formats are encoded in passlib's
+// "ab64" alphabet, that is standard base64 without padding where "." replaces
+// "+".
+//
+// The bcrypt key of $bcrypt-sha256$ is the base64-encoded HMAC-SHA256 of the
+// key under the salt, and the base64-encoded SHA-256 of the key for the hashes
+// of the first version, such as $bcrypt-sha256$2b,12$salt$checksum.
+package passlib_crypt
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"encoding/base64"
+	"hash"
+	"strconv"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/bcrypt_crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.PASSLIB, New,
+		MagicPrefixPBKDF2, MagicPrefixPBKDF2SHA256, MagicPrefixPBKDF2SHA512,
+		MagicPrefixBcryptSHA256)
+}
+
+const (
+	MagicPrefix             = MagicPrefixPBKDF2SHA256
+	MagicPrefixPBKDF2       = "$pbkdf2$"
+	MagicPrefixPBKDF2SHA256 = "$pbkdf2-sha256$"
+	MagicPrefixPBKDF2SHA512 = "$pbkdf2-sha512$"
+	MagicPrefixBcryptSHA256 = "$bcrypt-sha256$"
+
+	SaltLenMin = 0  // in bytes
+	SaltLenMax = 16 // in bytes, as passlib generates
+	RoundsMin  = 1
+	RoundsMax  = 1<<31 - 1
+
+	BcryptRoundsDefault = 12
+)
+
+type pbkdf2Scheme struct {
+	hash   func() hash.Hash
+	rounds int // default number of iterations of passlib
+}
+
+var pbkdf2Schemes = map[string]pbkdf2Scheme{
+	MagicPrefixPBKDF2:       {sha1.New, 131000},
+	MagicPrefixPBKDF2SHA256: {sha256.New, 29000},
+	MagicPrefixPBKDF2SHA512: {sha512.New, 25000},
+}
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the passlib password hashing. The
+// magic prefix of its salt selects the scheme used when no salt is given.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix: []byte(MagicPrefix),
+			SaltLenMin:  SaltLenMin,
+			SaltLenMax:  SaltLenMax,
+			RoundsMin:   RoundsMin,
+			RoundsMax:   RoundsMax,
+		},
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = c.generateSalt(); err != nil {
+			return "", err
+		}
+	}
+
+	if bytes.HasPrefix(salt, []byte(MagicPrefixBcryptSHA256)) {
+		return c.generateBcryptSHA256(key, salt)
+	}
+	for prefix, s := range pbkdf2Schemes {
+		if bytes.HasPrefix(salt, []byte(prefix)) {
+			return c.generatePBKDF2(key, salt, prefix, s)
+		}
+	}
+	return "", common.ErrSaltPrefix
+}
+
+func (c *crypter) generatePBKDF2(key, salt []byte, prefix string, s pbkdf2Scheme) (string, error) {
+	rounds, rawSalt, err := c.decodePBKDF2(salt[len(prefix):])
+	if err != nil {
+		return "", err
+	}
+
+	sum := pbkdf2.Key(key, rawSalt, rounds, s.hash().Size(), s.hash)
+
+	buf := bytes.Buffer{}
+	buf.WriteString(prefix)
+	buf.WriteString(strconv.Itoa(rounds))
+	buf.WriteByte('$')
+	buf.WriteString(pbkdf2.AB64.EncodeToString(rawSalt))
+	buf.WriteByte('$')
+	buf.WriteString(pbkdf2.AB64.EncodeToString(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) generateBcryptSHA256(key, salt []byte) (string, error) {
+	version, ident, rounds, bcryptSalt, err := decodeBcryptSHA256(salt[len(MagicPrefixBcryptSHA256):])
+	if err != nil {
+		return "", err
+	}
+
+	var sum []byte
+	if version == 1 {
+		s := sha256.Sum256(key)
+		sum = s[:]
+	} else {
+		mac := hmac.New(sha256.New, bcryptSalt)
+		mac.Write(key)
+		sum = mac.Sum(nil)
+	}
+	bcryptKey := []byte(base64.StdEncoding.EncodeToString(sum))
+	internal.CleanSensitiveData(sum)
+
+	setting := "$" + ident + "$" + twoDigits(rounds) + "$" + string(bcryptSalt)
+	hashed, err := bcrypt_crypt.New().Generate(bcryptKey, []byte(setting))
+	internal.CleanSensitiveData(bcryptKey)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	buf.WriteString(MagicPrefixBcryptSHA256)
+	if version == 1 {
+		buf.WriteString(ident)
+		buf.WriteByte(',')
+	} else {
+		buf.WriteString("v=2,t=")
+		buf.WriteString(ident)
+		buf.WriteString(",r=")
+	}
+	buf.WriteString(strconv.Itoa(rounds))
+	buf.WriteByte('$')
+	// The salt and the checksum follow the parameters in the bcrypt hash.
+	rest := hashed[len(setting)-len(bcryptSalt):]
+	buf.WriteString(rest[:len(bcryptSalt)])
+	buf.WriteByte('$')
+	buf.WriteString(rest[len(bcryptSalt):])
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the number of iterations for PBKDF2, and the base-2 logarithm
+// of the number of rounds for bcrypt.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	raw := []byte(hashedKey)
+	if bytes.HasPrefix(raw, []byte(MagicPrefixBcryptSHA256)) {
+		_, _, rounds, _, err := decodeBcryptSHA256(raw[len(MagicPrefixBcryptSHA256):])
+		return rounds, err
+	}
+	for prefix := range pbkdf2Schemes {
+		if bytes.HasPrefix(raw, []byte(prefix)) {
+			rounds, _, err := c.decodePBKDF2(raw[len(prefix):])
+			return rounds, err
+		}
+	}
+	return 0, common.ErrSaltPrefix
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// generateSalt returns a setting for the scheme of the magic prefix, with the
+// default parameters of passlib and a random salt.
+func (c *crypter) generateSalt() ([]byte, error) {
+	prefix := string(c.Salt.MagicPrefix)
+
+	buf := bytes.Buffer{}
+	buf.WriteString(prefix)
+	if prefix == MagicPrefixBcryptSHA256 {
+		salt := make([]byte, 16)
+		rand.Read(salt)
+		buf.WriteString("v=2,t=2b,r=")
+		buf.WriteString(strconv.Itoa(BcryptRoundsDefault))
+		buf.WriteByte('$')
+		buf.Write(common.Base64_Bcrypt(salt))
+		return buf.Bytes(), nil
+	}
+
+	s, ok := pbkdf2Schemes[prefix]
+	if !ok {
+		return nil, common.ErrSaltPrefix
+	}
+	salt := make([]byte, c.Salt.SaltLenMax)
+	rand.Read(salt)
+	buf.WriteString(strconv.Itoa(s.rounds))
+	buf.WriteByte('$')
+	buf.WriteString(pbkdf2.AB64.EncodeToString(salt))
+	return buf.Bytes(), nil
+}
+
+// decodePBKDF2 returns the number of iterations and the decoded salt of the
+// setting which follows a PBKDF2 magic prefix.
+func (c *crypter) decodePBKDF2(raw []byte) (rounds int, salt []byte, err error) {
+	fields := bytes.Split(raw, []byte{'$'})
+	if len(fields) < 2 || len(fields) > 3 {
+		return 0, nil, common.ErrSaltFormat
+	}
+	rounds, ok := internal.ParseInt(fields[0])
+	if !ok || rounds < c.Salt.RoundsMin || rounds > c.Salt.RoundsMax {
+		return 0, nil, common.ErrSaltRounds
+	}
+	if salt, err = pbkdf2.AB64.DecodeString(string(fields[1])); err != nil || len(salt) < c.Salt.SaltLenMin {
+		return 0, nil, common.ErrSaltFormat
+	}
+	return rounds, salt, nil
+}
+
+// decodeBcryptSHA256 returns the version, the bcrypt variant, the base-2
+// logarithm of the number of rounds and the salt of the setting which follows
+// the $bcrypt-sha256$ magic prefix.
+func decodeBcryptSHA256(raw []byte) (version int, ident string, rounds int, salt []byte, err error) {
+	fields := bytes.Split(raw, []byte{'$'})
+	if len(fields) < 2 || len(fields) > 3 {
+		err = common.ErrSaltFormat
+		return
+	}
+
+	params := fields[0]
+	var roundsText []byte
+	if bytes.HasPrefix(params, []byte("v=2,t=")) {
+		version = 2
+		params = params[len("v=2,t="):]
+		i := bytes.Index(params, []byte(",r="))
+		if i < 0 {
+			err = common.ErrSaltFormat
+			return
+		}
+		ident, roundsText = string(params[:i]), params[i+len(",r="):]
+	} else {
+		version = 1
+		i := bytes.IndexByte(params, ',')
+		if i < 0 {
+			err = common.ErrSaltFormat
+			return
+		}
+		ident, roundsText = string(params[:i]), params[i+1:]
+	}
+	if ident != "2a" && ident != "2b" {
+		err = common.ErrSaltFormat
+		return
+	}
+	rounds, ok := internal.ParseInt(roundsText)
+	if !ok || rounds < bcrypt_crypt.RoundsMin || rounds > bcrypt_crypt.RoundsMax {
+		err = common.ErrSaltRounds
+		return
+	}
+	if salt = fields[1]; len(salt) != bcrypt_crypt.SaltLenMax {
+		err = common.ErrSaltFormat
+		return
+	}
+	return
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
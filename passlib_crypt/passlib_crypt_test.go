@@ -0,0 +1,138 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package passlib_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+var passlibCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("$pbkdf2-sha256$6400$0ZrzXitFSGltTQnBWOsdAw$Y11AchqV4b0sUisdZd0Xr97KWoymNE0LNNrnEgY4H9M"),
+			[]byte("password"),
+			"$pbkdf2-sha256$6400$0ZrzXitFSGltTQnBWOsdAw$Y11AchqV4b0sUisdZd0Xr97KWoymNE0LNNrnEgY4H9M",
+			6400,
+		},
+		{
+			[]byte("$pbkdf2$1000$0ZrzXitFSGltTQnBWOsdAw$"),
+			[]byte("password"),
+			"$pbkdf2$1000$0ZrzXitFSGltTQnBWOsdAw$pc9H.tkuclrjAGOM456SiFw6zQ0",
+			1000,
+		},
+		{
+			[]byte("$pbkdf2-sha512$1000$0ZrzXitFSGltTQnBWOsdAw"),
+			[]byte("password"),
+			"$pbkdf2-sha512$1000$0ZrzXitFSGltTQnBWOsdAw$8RRS9cgnQ1Bxj1YBmQc4S5HLzWgT5wIZPONFu52g82rBN8nDwJ02CGm5pcLhXo10ne9d7fRx/1oTQgszUqMQEw",
+			1000,
+		},
+		{
+			[]byte("$bcrypt-sha256$v=2,t=2b,r=4$abcdefghijklmnopqrstuu$"),
+			[]byte("password"),
+			"$bcrypt-sha256$v=2,t=2b,r=4$abcdefghijklmnopqrstuu$6eeTxYH54oiZ0Z7.zjNaUH4ORExX7RG",
+			4,
+		},
+		{
+			[]byte("$bcrypt-sha256$2b,4$abcdefghijklmnopqrstuu"),
+			[]byte("password"),
+			"$bcrypt-sha256$2b,4$abcdefghijklmnopqrstuu$NjBgm1f5PAJarK9STLtnoQTHtx3RGk.",
+			4,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := passlibCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := passlibCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"$pbkdf2-sha1$1000$0ZrzXitFSGltTQnBWOsdAw",
+		"$pbkdf2-sha256$1000",
+		"$pbkdf2-sha256$01000$0ZrzXitFSGltTQnBWOsdAw",
+		"$pbkdf2-sha256$1000$0ZrzXitFSGltTQnBWOsdAw==",
+		"$pbkdf2-sha256$1000$0Zrz+itFSGltTQnBWOsdAw",
+		"$bcrypt-sha256$v=2,t=2y,r=4$abcdefghijklmnopqrstuu",
+		"$bcrypt-sha256$v=2,t=2b,r=3$abcdefghijklmnopqrstuu",
+		"$bcrypt-sha256$v=2,t=2b$abcdefghijklmnopqrstuu",
+		"$bcrypt-sha256$2b,4$abcdefghijklmnopqrstu",
+	}
+	for i, d := range data {
+		if _, err := passlibCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of the passlib test suite and documentation.
+	data := []string{
+		"$pbkdf2$1212$OB.dtnSEXZK8U5cgxU/GYQ$y5LKPOplRmok7CZp/aqVDVg8zGI",
+		"$pbkdf2-sha256$1212$4vjV83LKPjQzk31VI4E0Vw$hsYF68OiOUPdDZ1Fg.fJPeq1h/gXXY7acBp9/6c.tmQ",
+		"$pbkdf2-sha512$1212$RHY0Fr3IDMSVO/RSZyb5ow$eNLfBK.eVozomMr.1gYa17k9B7KIK25NOEshvhrSX.esqY3s.FvWZViXz4KoLlQI.BzY/YTNJOiKc5gBYFYGww",
+		"$bcrypt-sha256$v=2,t=2b,r=12$n79VH.0Q2TMWmt3Oqt9uku$Kq4Noyk3094Y2QlB8NdRT8SvGiI4ft2",
+		"$bcrypt-sha256$2a,12$LrmaIX5x4TRtAwEfwJZa1.$2ehnw6LvuIUTM0iz4iz9hTxv21B6KFO",
+	}
+	for i, d := range data {
+		if err := passlibCrypt.Verify(d, []byte("password")); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err := passlibCrypt.Verify(d, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	prefixes := []string{
+		MagicPrefixPBKDF2,
+		MagicPrefixPBKDF2SHA256,
+		MagicPrefixPBKDF2SHA512,
+		MagicPrefixBcryptSHA256,
+	}
+	for i, prefix := range prefixes {
+		c := New()
+		c.SetSalt(common.Salt{
+			MagicPrefix: []byte(prefix),
+			SaltLenMin:  SaltLenMin,
+			SaltLenMax:  SaltLenMax,
+			RoundsMin:   RoundsMin,
+			RoundsMax:   RoundsMax,
+		})
+		hash, err := c.Generate([]byte("password"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(hash, prefix) {
+			t.Errorf("Test %d failed: unexpected hash %s", i, hash)
+		}
+		if err = passlibCrypt.Verify(hash, []byte("password")); err != nil {
+			t.Errorf("Test %d failed: %s", i, hash)
+		}
+	}
+}
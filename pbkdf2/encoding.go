@@ -0,0 +1,60 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package pbkdf2
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"strings"
+)
+
+// An Encoding is a binary-to-text encoding of salts and derived keys.
+type Encoding interface {
+	EncodedLen(n int) int
+	EncodeToString(src []byte) string
+	DecodeString(s string) ([]byte, error)
+}
+
+const cryptAlphabet = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+var (
+	// Base64 is the standard base64 encoding with padding, as used by
+	// Django, Atlassian, ASP.NET Identity and PostgreSQL SCRAM.
+	Base64 Encoding = base64.StdEncoding.Strict()
+
+	// AB64 is the "adapted base64" encoding of passlib, that is the standard
+	// base64 encoding without padding where "." replaces "+".
+	AB64 Encoding = base64.NewEncoding("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789./").
+		WithPadding(base64.NoPadding).Strict()
+
+	// Crypt is the standard base64 encoding without padding over the
+	// alphabet of crypt(3), as used by Cisco type 8. Unlike
+	// common.Base64_24Bit, it keeps the bit order of base64.
+	Crypt Encoding = base64.NewEncoding(cryptAlphabet).
+		WithPadding(base64.NoPadding).Strict()
+
+	// Hex is the lowercase hexadecimal encoding, as used by Werkzeug and
+	// Spring Security.
+	Hex Encoding = hexEncoding{upper: false}
+
+	// HexUpper is the uppercase hexadecimal encoding, as used by GRUB.
+	HexUpper Encoding = hexEncoding{upper: true}
+)
+
+// hexEncoding encodes in either case, and decodes both.
+type hexEncoding struct{ upper bool }
+
+func (e hexEncoding) EncodedLen(n int) int { return hex.EncodedLen(n) }
+
+func (e hexEncoding) EncodeToString(src []byte) string {
+	if e.upper {
+		return strings.ToUpper(hex.EncodeToString(src))
+	}
+	return hex.EncodeToString(src)
+}
+
+func (e hexEncoding) DecodeString(s string) ([]byte, error) {
+	return hex.DecodeString(s)
+}
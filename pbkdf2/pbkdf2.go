@@ -0,0 +1,72 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package pbkdf2 implements the PBKDF2 key derivation function of RFC 8018
+// with an HMAC pseudorandom function, the core of the PBKDF2-based crypters
+// of this module, and the encodings their hashed keys are written in.
+package pbkdf2
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/binary"
+	"hash"
+)
+
+// Key derives a key of keyLen bytes from the password and the salt, with iter
+// iterations of HMAC over the hash function h.
+//
+// Key panics if iter or keyLen is less than 1, or if keyLen exceeds the
+// (2^32 - 1) blocks of the hash size which RFC 8018 allows. The crypters
+// validate their parameters beforehand.
+func Key(password, salt []byte, iter, keyLen int, h func() hash.Hash) []byte {
+	prf := hmac.New(h, password)
+	size := prf.Size()
+	if iter < 1 || keyLen < 1 || uint64(keyLen) > (1<<32-1)*uint64(size) {
+		panic("pbkdf2: invalid iteration count or key length")
+	}
+	blocks := (keyLen + size - 1) / size
+
+	dk := make([]byte, 0, blocks*size)
+	var counter [4]byte
+	u := make([]byte, size)
+	for block := 1; block <= blocks; block++ {
+		// U_1 = PRF(password, salt || INT(block))
+		prf.Reset()
+		prf.Write(salt)
+		binary.BigEndian.PutUint32(counter[:], uint32(block))
+		prf.Write(counter[:])
+		dk = prf.Sum(dk)
+		t := dk[len(dk)-size:]
+		copy(u, t)
+
+		// T = U_1 ^ U_2 ^ ... ^ U_iter
+		for n := 1; n < iter; n++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for i := range u {
+				t[i] ^= u[i]
+			}
+		}
+	}
+	return dk[:keyLen]
+}
+
+// SHA1 derives a key of keyLen bytes with PBKDF2-HMAC-SHA1.
+func SHA1(password, salt []byte, iter, keyLen int) []byte {
+	return Key(password, salt, iter, keyLen, sha1.New)
+}
+
+// SHA256 derives a key of keyLen bytes with PBKDF2-HMAC-SHA256.
+func SHA256(password, salt []byte, iter, keyLen int) []byte {
+	return Key(password, salt, iter, keyLen, sha256.New)
+}
+
+// SHA512 derives a key of keyLen bytes with PBKDF2-HMAC-SHA512.
+func SHA512(password, salt []byte, iter, keyLen int) []byte {
+	return Key(password, salt, iter, keyLen, sha512.New)
+}
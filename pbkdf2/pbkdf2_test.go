@@ -0,0 +1,140 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package pbkdf2
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"hash"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	data := []struct {
+		h        func() hash.Hash
+		password string
+		salt     string
+		iter     int
+		out      string
+	}{
+		// RFC 6070.
+		{sha1.New, "password", "salt", 1, "0c60c80f961f0e71f3a9b524af6012062fe037a6"},
+		{sha1.New, "password", "salt", 2, "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957"},
+		{sha1.New, "password", "salt", 4096, "4b007901b765489abead49d926f721d065a429c1"},
+		{
+			sha1.New, "passwordPASSWORDpassword", "saltSALTsaltSALTsaltSALTsaltSALTsalt", 4096,
+			"3d2eec4fe41c849b80c8d83662c0e44a8b291a964cf2f07038",
+		},
+		{sha1.New, "pass\x00word", "sa\x00lt", 4096, "56fa6aa75548099dcc37d7f03425e0c3"},
+		{
+			sha256.New, "password", "salt", 1,
+			"120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b",
+		},
+		{
+			sha256.New, "password", "salt", 4096,
+			"c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a",
+		},
+		{
+			sha512.New, "password", "salt", 1,
+			"867f70cf1ade02cff3752599a3a53dc4af34c7a669815ae5d513554e1c8cf252" +
+				"c02d470a285a0501bad999bfe943c08f050235d7d68b1da55e63f73b60a57fce",
+		},
+	}
+
+	for i, d := range data {
+		want, _ := hex.DecodeString(d.out)
+		out := Key([]byte(d.password), []byte(d.salt), d.iter, len(want), d.h)
+		if hex.EncodeToString(out) != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %x", i, d.out, out)
+		}
+	}
+}
+
+func TestKeyInvalid(t *testing.T) {
+	data := []struct{ iter, keyLen int }{{0, 20}, {-1, 20}, {1, 0}, {1, -1}}
+	for i, d := range data {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Test %d failed: %v was accepted", i, d)
+				}
+			}()
+			SHA1([]byte("password"), []byte("salt"), d.iter, d.keyLen)
+		}()
+	}
+}
+
+func TestKeyHelpers(t *testing.T) {
+	data := []struct {
+		key  func(password, salt []byte, iter, keyLen int) []byte
+		iter int
+		out  string
+	}{
+		{SHA1, 2, "ea6c014dc72d6f8ccd1ed92ace1d41f0d8de8957cae93136266537a8d7bf4b76"},
+		{SHA256, 4096, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{SHA512, 2, "e1d9c16aa681708a45f5c7c4e215ceb66e011a2e"},
+	}
+	for i, d := range data {
+		out := d.key([]byte("password"), []byte("salt"), d.iter, len(d.out)/2)
+		if hex.EncodeToString(out) != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %x", i, d.out, out)
+		}
+	}
+}
+
+func TestEncoding(t *testing.T) {
+	key, _ := hex.DecodeString("c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a")
+	data := []struct {
+		enc Encoding
+		src []byte
+		out string
+	}{
+		{Base64, key, "xeR41ZKIyEGqUw22hFxMjZYok6ABzk4RpJY4c6qYE0o="},
+		{Base64, []byte{0xfb, 0xff}, "+/8="},
+		{AB64, key, "xeR41ZKIyEGqUw22hFxMjZYok6ABzk4RpJY4c6qYE0o"},
+		{AB64, []byte{0xfb, 0xff}, "./8"},
+		{Crypt, key, "lSFspN86m24eIkqqV3lAXNMcYu./nYsFd7MsQueM2oc"},
+		{Crypt, []byte{0xfb, 0xff}, "yzw"},
+		{Hex, key, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+		{HexUpper, key, "C5E478D59288C841AA530DB6845C4C8D962893A001CE4E11A4963873AA98134A"},
+	}
+
+	for i, d := range data {
+		out := d.enc.EncodeToString(d.src)
+		if out != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, out)
+		}
+		if n := d.enc.EncodedLen(len(d.src)); n != len(d.out) {
+			t.Errorf("Test %d failed\nExpected length: %d, got: %d", i, len(d.out), n)
+		}
+		src, err := d.enc.DecodeString(d.out)
+		if err != nil || !bytes.Equal(src, d.src) {
+			t.Errorf("Test %d failed: %s was decoded to %x, %v", i, d.out, src, err)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	data := []struct {
+		enc Encoding
+		s   string
+	}{
+		{Base64, "+/8"},
+		{Base64, "+/9="},
+		{AB64, "+/8"},
+		{AB64, "./8="},
+		{Crypt, "yz+"},
+		{Hex, "c5e"},
+		{HexUpper, "C5EG"},
+	}
+	for i, d := range data {
+		if _, err := d.enc.DecodeString(d.s); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d.s)
+		}
+	}
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package phpass_crypt implements the portable hashes of the phpass framework
+// by Solar Designer, used by WordPress and, with another prefix, by phpBB:
+//
+//	$P$984478476IagS59wHZvyQMArzfx58u.
+//	$H$9saltsaltTPYWOFleH9nxJ26A2VSHl1
+//
+// The setting is made of the magic prefix, the base-2 logarithm of the number
+// of iterations in a single character, and a salt of 8 characters. The key is
+// hashed with iterated MD5, so this scheme must only be used to verify
+// existing hashes.
+package phpass_crypt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/rand"
+	"crypto/subtle"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.PHPASS, New, MagicPrefix, MagicPrefixPHPBB)
+}
+
+const (
+	MagicPrefix      = "$P$"
+	MagicPrefixPHPBB = "$H$"
+	SaltLenMin       = 8
+	SaltLenMax       = 8
+	RoundsMin        = 7 // base-2 logarithm of the number of iterations
+	RoundsMax        = 30
+	RoundsDefault    = 13 // "B", as WordPress generates
+)
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the phpass portable hashing.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+// GenerateSalt returns a setting with the given base-2 logarithm of the number
+// of iterations and a random salt.
+func GenerateSalt(rounds int) ([]byte, error) {
+	return generateSalt([]byte(MagicPrefix), rounds)
+}
+
+func generateSalt(magicPrefix []byte, rounds int) ([]byte, error) {
+	if rounds < RoundsMin || rounds > RoundsMax {
+		return nil, common.ErrSaltRounds
+	}
+
+	salt := make([]byte, SaltLenMax*6/8)
+	rand.Read(salt)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(magicPrefix) + 1 + SaltLenMax)
+	buf.Write(magicPrefix)
+	buf.Write(common.Base64_24BitUint(uint32(rounds), 1))
+	buf.Write(common.Base64_24Bit(salt))
+	return buf.Bytes(), nil
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = generateSalt(c.Salt.MagicPrefix, c.Salt.RoundsDefault); err != nil {
+			return "", err
+		}
+	}
+	setting, rounds, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	h := md5.New()
+	h.Write(setting[len(setting)-c.Salt.SaltLenMax:])
+	h.Write(key)
+	sum := h.Sum(nil)
+	for i := 0; i < 1<<uint(rounds); i++ {
+		h.Reset()
+		h.Write(sum)
+		h.Write(key)
+		sum = h.Sum(sum[:0])
+	}
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(setting) + 22)
+	buf.Write(setting)
+	buf.Write(common.Base64_24Bit(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the base-2 logarithm of the number of iterations.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	_, rounds, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return rounds, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decode returns the setting part of raw, which is reproduced in the output,
+// and the base-2 logarithm of the number of iterations. Both the WordPress and
+// the phpBB prefixes are accepted.
+func (c *crypter) decode(raw []byte) (setting []byte, rounds int, err error) {
+	var prefixLen int
+	switch {
+	case bytes.HasPrefix(raw, c.Salt.MagicPrefix):
+		prefixLen = len(c.Salt.MagicPrefix)
+	case bytes.HasPrefix(raw, []byte(MagicPrefix)), bytes.HasPrefix(raw, []byte(MagicPrefixPHPBB)):
+		prefixLen = len(MagicPrefix)
+	default:
+		return nil, 0, common.ErrSaltPrefix
+	}
+	if len(raw) < prefixLen+1+c.Salt.SaltLenMax {
+		return nil, 0, common.ErrSaltFormat
+	}
+
+	n, err := common.DecodeBase64_24BitUint(raw[prefixLen : prefixLen+1])
+	if err != nil || int(n) < c.Salt.RoundsMin || int(n) > c.Salt.RoundsMax {
+		return nil, 0, common.ErrSaltRounds
+	}
+	return raw[:prefixLen+1+c.Salt.SaltLenMax], int(n), nil
+}
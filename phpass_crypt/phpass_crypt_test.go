@@ -0,0 +1,137 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package phpass_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var phpassCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("$P$984478476IagS59wHZvyQMArzfx58u."),
+			[]byte("hashcat"),
+			"$P$984478476IagS59wHZvyQMArzfx58u.",
+			11,
+		},
+		{
+			[]byte("$P$9IQRaTwmf"),
+			[]byte("test12345"),
+			"$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0",
+			11,
+		},
+		{
+			[]byte("$P$Bsaltsalt"),
+			[]byte("password"),
+			"$P$BsaltsaltnH1n4.V11.zjFlE3mwm.O1",
+			13,
+		},
+		{
+			[]byte("$P$5saltsalt"),
+			[]byte("password"),
+			"$P$5saltsalt.dw5Zgkxy7Q/ZvpQhE5iw0",
+			7,
+		},
+		{
+			[]byte("$H$9saltsalt"),
+			[]byte("password"),
+			"$H$9saltsaltTPYWOFleH9nxJ26A2VSHl1",
+			11,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := phpassCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := phpassCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"$Q$Bsaltsalt",
+		"$P$Bsalt",
+		"$P$4saltsalt",
+		"$P$Tsaltsalt",
+		"$P$:saltsalt",
+	}
+	for i, d := range data {
+		if _, err := phpassCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	salt, err := GenerateSalt(8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(salt), "$P$6") || len(salt) != 12 {
+		t.Errorf("Unexpected salt %s", salt)
+	}
+
+	for _, rounds := range []int{RoundsMin - 1, RoundsMax + 1} {
+		if _, err := GenerateSalt(rounds); err == nil {
+			t.Errorf("Rounds %d were accepted", rounds)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of the phpass test suite, of the hashcat examples, and of
+	// the reference implementation.
+	data := []struct {
+		hash string
+		key  []byte
+	}{
+		{"$P$9IQRaTwmfeRo7ud9Fh4E2PdI0S3r.L0", []byte("test12345")},
+		{"$P$984478476IagS59wHZvyQMArzfx58u.", []byte("hashcat")},
+		{"$P$BWkw3IlqgrXI2A08ew74zTZhIctOnN0", []byte("correct horse battery staple")},
+		{"$H$9Zq1mtJqTARhqJ85/A0ZSMtUfnFKVN/", []byte("phpBB")},
+		{"$P$Cabcdefgh5tMy/JcxChZs08MImoCyJ0", []byte("")},
+	}
+	for i, d := range data {
+		if err := phpassCrypt.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := phpassCrypt.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	hash, err := phpassCrypt.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, MagicPrefix+"B") {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = phpassCrypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verification failed: %s", hash)
+	}
+}
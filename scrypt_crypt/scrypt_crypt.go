@@ -0,0 +1,213 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package scrypt_crypt implements the "$7$" password hashing format of
+// libxcrypt, based on Colin Percival's scrypt key derivation function.
+//
+// The setting is made of the magic prefix, the base-2 logarithm of N in a
+// single character, r and p in five characters each, and the salt which is
+// used as is:
+//
+//	$7$CU..../....salt
+//
+// Generated salts are made of SaltLenMax characters, but salts of any length
+// are accepted. Hashed keys whose parameters need more than MemoryMax bytes
+// are rejected.
+//
+// The specification for scrypt can be found here:
+// https://www.tarsnap.com/scrypt/scrypt.pdf
+package scrypt_crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"math/bits"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.SCRYPT, New, MagicPrefix)
+}
+
+const (
+	MagicPrefix   = "$7$"
+	SaltLenMin    = 0
+	SaltLenMax    = 22 // length of generated salts, as libxcrypt generates
+	RoundsMin     = 2  // base-2 logarithm of N, as libxcrypt accepts
+	RoundsMax     = 63
+	RoundsDefault = 14
+	RDefault      = 32
+	PDefault      = 1
+	HashLen       = 32
+	MemoryMax     = internal.ScryptMemoryMax // in bytes, bounding 128*N*r*p
+)
+
+const (
+	itoa64    = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	paramsLen = 1 + 5 + 5
+)
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the scrypt password hashing.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+// GenerateSalt returns a setting with the given scrypt parameters and a random
+// salt of SaltLenMax characters. N must be a power of two of at least 4, and
+// 128*N*r*p must not exceed MemoryMax.
+func GenerateSalt(N, r, p int) ([]byte, error) {
+	return generateSalt([]byte(MagicPrefix), N, r, p)
+}
+
+func generateSalt(magicPrefix []byte, N, r, p int) ([]byte, error) {
+	if N < 1<<RoundsMin || N&(N-1) != 0 || r < 1 || r >= 1<<30 || p < 1 || p >= 1<<30 ||
+		!internal.ScryptParamsOK(uint64(N), uint64(r), uint64(p)) {
+		return nil, common.ErrSaltRounds
+	}
+
+	salt := make([]byte, SaltLenMax*6/8)
+	rand.Read(salt)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(magicPrefix) + paramsLen + SaltLenMax)
+	buf.Write(magicPrefix)
+	buf.WriteByte(itoa64[bits.TrailingZeros(uint(N))])
+	buf.Write(encodeUint30(uint32(r)))
+	buf.Write(encodeUint30(uint32(p)))
+	buf.Write(common.Base64_24Bit(salt))
+	return buf.Bytes(), nil
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		salt, err = generateSalt(c.Salt.MagicPrefix, 1<<RoundsDefault, RDefault, PDefault)
+		if err != nil {
+			return "", err
+		}
+	}
+	setting, nLog2, r, p, salt, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	sum, err := scrypt.Key(key, salt, 1<<uint(nLog2), r, p, HashLen)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(setting) + 1 + 43)
+	buf.Write(setting)
+	buf.WriteByte('$')
+	buf.Write(common.Base64_24Bit(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the base-2 logarithm of N.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	_, nLog2, _, _, _, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return nLog2, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decode splits raw into the setting part which is reproduced in the output,
+// the scrypt parameters and the salt.
+func (c *crypter) decode(raw []byte) (setting []byte, nLog2, r, p int, salt []byte, err error) {
+	if !bytes.HasPrefix(raw, c.Salt.MagicPrefix) {
+		err = common.ErrSaltPrefix
+		return
+	}
+	rest := raw[len(c.Salt.MagicPrefix):]
+	if len(rest) < paramsLen {
+		err = common.ErrSaltFormat
+		return
+	}
+
+	nLog2 = strings.IndexByte(itoa64, rest[0])
+	if nLog2 < c.Salt.RoundsMin || nLog2 > c.Salt.RoundsMax {
+		err = common.ErrSaltRounds
+		return
+	}
+	rr, ok1 := decodeUint30(rest[1:6])
+	pp, ok2 := decodeUint30(rest[6:11])
+	if !ok1 || !ok2 {
+		err = common.ErrSaltFormat
+		return
+	}
+	if !internal.ScryptParamsOK(1<<uint(nLog2), uint64(rr), uint64(pp)) {
+		err = common.ErrSaltRounds
+		return
+	}
+	r, p = int(rr), int(pp)
+
+	salt = rest[paramsLen:]
+	if i := bytes.LastIndexByte(salt, '$'); i >= 0 {
+		salt = salt[:i]
+	}
+	// Any salt up to the hash is accepted, as libxcrypt does; SaltLenMax
+	// only bounds the generated ones.
+	if len(salt) < c.Salt.SaltLenMin {
+		err = common.ErrSaltFormat
+		return
+	}
+
+	setting = raw[:len(c.Salt.MagicPrefix)+paramsLen+len(salt)]
+	return
+}
+
+// encodeUint30 encodes the 30 low bits of v in five characters, least
+// significant first.
+func encodeUint30(v uint32) []byte {
+	dst := make([]byte, 5)
+	for i := range dst {
+		dst[i] = itoa64[v&0x3f]
+		v >>= 6
+	}
+	return dst
+}
+
+func decodeUint30(src []byte) (v uint32, ok bool) {
+	for i := len(src) - 1; i >= 0; i-- {
+		c := strings.IndexByte(itoa64, src[i])
+		if c < 0 {
+			return 0, false
+		}
+		v = v<<6 | uint32(c)
+	}
+	return v, true
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package scrypt_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var scryptCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("$7$C6..../....SodiumChloride"),
+			[]byte("password"),
+			"$7$C6..../....SodiumChloride$6OIeehEnzbyu949sLkdyNyp6EorTTZ52ToM3ucR5RK7",
+			14,
+		},
+		{
+			[]byte("$7$AU..../....LdJMENpBABJJ3hIHjB1Bi."),
+			[]byte("Hello world!"),
+			"$7$AU..../....LdJMENpBABJJ3hIHjB1Bi.$Yp6wwlf7kVT.Gh18IyBxHDevVKc0BTDuol7ofm9Xhp9",
+			12,
+		},
+		{
+			// p = 2
+			[]byte("$7$96....0....empty$USi5Qk010NWhKxg3a.qV0shOh7pYbx4cPIe1BOAegy0"),
+			[]byte(""),
+			"$7$96....0....empty$USi5Qk010NWhKxg3a.qV0shOh7pYbx4cPIe1BOAegy0",
+			11,
+		},
+		{
+			// A salt of 40 characters, longer than the generated ones.
+			[]byte("$7$CU..../....wYmCcdsxLtkA6lRHdUsE34nNEFKPVzUa/MNYdvmN"),
+			[]byte("pleaseletmein"),
+			"$7$CU..../....wYmCcdsxLtkA6lRHdUsE34nNEFKPVzUa/MNYdvmN$mn8X1Moc2SfIX6rsqql6n0Q7ssRanQcG.6jabwqGEW8",
+			14,
+		},
+		{
+			[]byte("$7$C6..../...."),
+			[]byte("password"),
+			"$7$C6..../....$sr5D.9nzHohHQnwRgN58z6fuWG8mszL.wA4TuSk.6z3",
+			14,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := scryptCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := scryptCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"$7$.6..../....SodiumChloride",
+		"$7$/6..../....SodiumChloride",
+		"$7$06..../...",
+		"$7$Y/..../..../....$salt$",
+		"$7$J6..../....SodiumChloride",
+		"$7$C6....zzzzzSodiumChloride",
+	}
+	for i, d := range data {
+		if _, err := scryptCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	salt, err := GenerateSalt(1024, 8, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(salt), "$7$86....0....") || len(salt) != 14+SaltLenMax {
+		t.Errorf("Unexpected salt %s", salt)
+	}
+
+	for _, p := range [][3]int{{1000, 8, 1}, {1, 8, 1}, {2, 8, 1}, {1024, 0, 1}, {1024, 8, 0}, {1 << 20, 9, 1}, {1 << 20, 8, 2}} {
+		if _, err = GenerateSalt(p[0], p[1], p[2]); err == nil {
+			t.Errorf("Parameters %v were accepted", p)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	data := [][]byte{
+		[]byte("password"),
+		[]byte("12345"),
+		[]byte("That's amazing! I've got the same combination on my luggage!"),
+		[]byte("And change the combination on my luggage!"),
+		[]byte("         random  spa  c    ing."),
+		[]byte("94ajflkvjzpe8u3&*j1k513KLJ&*()"),
+	}
+	for i, d := range data {
+		hash, err := scryptCrypt.Generate(d, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = scryptCrypt.Verify(hash, d); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err = scryptCrypt.Verify(hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package sha1_crypt implements the SHA1-based crypt(3) function of NetBSD,
+// which iterates HMAC-SHA1 keyed with the key:
+//
+//	$sha1$24680$salt$checksum
+//
+// The first message is the salt followed by the magic prefix and the number of
+// iterations, and each following message is the previous digest.
+package sha1_crypt
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/binary"
+	"strconv"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.SHA1, New, MagicPrefix)
+}
+
+const (
+	MagicPrefix   = "$sha1$"
+	SaltLenMin    = 1
+	SaltLenMax    = 64
+	SaltLenGen    = 8 // as NetBSD generates
+	RoundsMin     = 1
+	RoundsMax     = 1<<31 - 1
+	RoundsDefault = 24680
+)
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the NetBSD SHA1-crypt password
+// hashing.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+// GenerateSalt returns a setting with a random salt of SaltLenGen characters
+// and a number of iterations drawn, as NetBSD does, between three quarters of
+// rounds and rounds.
+func GenerateSalt(rounds int) ([]byte, error) {
+	return generateSalt([]byte(MagicPrefix), rounds)
+}
+
+func generateSalt(magicPrefix []byte, rounds int) ([]byte, error) {
+	if rounds < RoundsMin || rounds > RoundsMax {
+		return nil, common.ErrSaltRounds
+	}
+
+	buf := make([]byte, 4+SaltLenGen*6/8)
+	rand.Read(buf)
+	if rounds >= 4 {
+		rounds -= int(binary.LittleEndian.Uint32(buf) % uint32(rounds/4))
+	}
+
+	out := bytes.Buffer{}
+	out.Write(magicPrefix)
+	out.WriteString(strconv.Itoa(rounds))
+	out.WriteByte('$')
+	out.Write(common.Base64_24Bit(buf[4:]))
+	out.WriteByte('$')
+	return out.Bytes(), nil
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = generateSalt(c.Salt.MagicPrefix, c.Salt.RoundsDefault); err != nil {
+			return "", err
+		}
+	}
+	rounds, salt, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	// The number of iterations is written without leading zeros.
+	iterations := strconv.AppendUint(nil, uint64(rounds), 10)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(salt)
+	mac.Write(c.Salt.MagicPrefix)
+	mac.Write(iterations)
+	sum := mac.Sum(nil)
+	for i := uint32(1); i < rounds; i++ {
+		mac.Reset()
+		mac.Write(sum)
+		sum = mac.Sum(sum[:0])
+	}
+
+	out := bytes.Buffer{}
+	out.Grow(len(c.Salt.MagicPrefix) + len(iterations) + 1 + len(salt) + 1 + 28)
+	out.Write(c.Salt.MagicPrefix)
+	out.Write(iterations)
+	out.WriteByte('$')
+	out.Write(salt)
+	out.WriteByte('$')
+	out.Write(common.Base64_24Bit([]byte{
+		sum[2], sum[1], sum[0],
+		sum[5], sum[4], sum[3],
+		sum[8], sum[7], sum[6],
+		sum[11], sum[10], sum[9],
+		sum[14], sum[13], sum[12],
+		sum[17], sum[16], sum[15],
+		sum[0], sum[19], sum[18],
+	}))
+	return out.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the number of iterations.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	rounds, _, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return int(rounds), nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decode returns the number of iterations and the salt of raw.
+func (c *crypter) decode(raw []byte) (rounds uint32, salt []byte, err error) {
+	if !bytes.HasPrefix(raw, c.Salt.MagicPrefix) {
+		return 0, nil, common.ErrSaltPrefix
+	}
+	rest := raw[len(c.Salt.MagicPrefix):]
+
+	i := bytes.IndexByte(rest, '$')
+	if i < 0 {
+		return 0, nil, common.ErrSaltFormat
+	}
+	n, err := strconv.ParseUint(string(rest[:i]), 10, 32)
+	if err != nil || n < uint64(c.Salt.RoundsMin) || n > uint64(c.Salt.RoundsMax) {
+		return 0, nil, common.ErrSaltRounds
+	}
+	rest = rest[i+1:]
+
+	if i = bytes.IndexByte(rest, '$'); i >= 0 {
+		rest = rest[:i]
+	}
+	if len(rest) < c.Salt.SaltLenMin || len(rest) > c.Salt.SaltLenMax {
+		return 0, nil, common.ErrSaltFormat
+	}
+	for _, ch := range rest {
+		if !isSaltChar(ch) {
+			return 0, nil, common.ErrSaltFormat
+		}
+	}
+	return uint32(n), rest, nil
+}
+
+func isSaltChar(ch byte) bool {
+	return ch == '.' || ch == '/' ||
+		'0' <= ch && ch <= '9' || 'A' <= ch && ch <= 'Z' || 'a' <= ch && ch <= 'z'
+}
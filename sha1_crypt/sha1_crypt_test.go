@@ -0,0 +1,143 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package sha1_crypt
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var sha1Crypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("$sha1$24680$saltsalt$"),
+			[]byte("password"),
+			"$sha1$24680$saltsalt$cjWYY8m.GxzIaOChpqX.LIZkGuOY",
+			24680,
+		},
+		{
+			[]byte("$sha1$24680$saltsalt"),
+			[]byte("password"),
+			"$sha1$24680$saltsalt$cjWYY8m.GxzIaOChpqX.LIZkGuOY",
+			24680,
+		},
+		{
+			[]byte("$sha1$1$salt$cOuL1AdqCoBciQVpRSrmHc00TNk5"),
+			[]byte("password"),
+			"$sha1$1$salt$cOuL1AdqCoBciQVpRSrmHc00TNk5",
+			1,
+		},
+		{
+			[]byte("$sha1$01$salt$"),
+			[]byte("password"),
+			"$sha1$1$salt$cOuL1AdqCoBciQVpRSrmHc00TNk5",
+			1,
+		},
+		{
+			[]byte("$sha1$5$sa$tttt"),
+			[]byte("password"),
+			"$sha1$5$sa$AfGHveGh6Hug8DiHVc2sGJrNBtRG",
+			5,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := sha1Crypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := sha1Crypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"$sha2$5$salt$",
+		"$sha1$5$$",
+		"$sha1$5$sa:t$",
+		"$sha1$x$salt$",
+		"$sha1$0$salt$",
+		"$sha1$2147483648$salt$",
+		"$sha1$4294967296$salt$",
+		"$sha1$5",
+	}
+	for i, d := range data {
+		if _, err := sha1Crypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		salt, err := GenerateSalt(1000)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fields := strings.Split(string(salt), "$")
+		if len(fields) != 5 || len(fields[3]) != SaltLenGen {
+			t.Fatalf("Unexpected salt %s", salt)
+		}
+		if rounds, _ := strconv.Atoi(fields[2]); rounds <= 750 || rounds > 1000 {
+			t.Fatalf("Unexpected number of iterations %s", salt)
+		}
+	}
+
+	if _, err := GenerateSalt(0); err == nil {
+		t.Errorf("Rounds 0 were accepted")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of libxcrypt.
+	data := []struct {
+		hash string
+		key  []byte
+	}{
+		{"$sha1$19703$iVdJqfSE$v4qYKl1zqYThwpjJAoKX6UvlHq/a", []byte("password")},
+		{"$sha1$1$salt$vF8iIzOqpBX7eX5KGQmkd6NOs8Yu", []byte("test")},
+		{"$sha1$480$abcdefgh$lLR8kvlMPgWLoP4BfjcKKwk9dgLN", []byte("")},
+		{"$sha1$24680$JQ3nAQJg$IMivxnJiPbM9BkdGCenF6CPTinHD", []byte("U*U*U*U*")},
+	}
+	for i, d := range data {
+		if err := sha1Crypt.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := sha1Crypt.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	hash, err := sha1Crypt.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, MagicPrefix) {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = sha1Crypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verification failed: %s", hash)
+	}
+}
@@ -0,0 +1,341 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package spring_crypt implements the format of the DelegatingPasswordEncoder
+// of Spring Security, where the hashed key is preceded by the identifier of
+// its encoder in braces:
+//
+//	{bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG
+//	{pbkdf2}5d923b44a6d129f3ddf3e3c8d29412723dcbde72445e8ef6bf3b508fbf17fa4ed4d6b99ca763d8dc
+//	{sha256}97cde38028ad898ebc02e690819fa220e88c62e0699403e94fff291cfffaf8410849f27605abcbc0
+//	{scrypt}$e0801$salt$hash
+//	{argon2}$argon2id$v=19$m=4096,t=3,p=1$salt$hash
+//	{noop}password
+//
+// The encoders of PasswordEncoderFactories are supported, with the defaults of
+// their "@SpringSecurity_v5_8" variants where they differ, except the legacy
+// MessageDigestPasswordEncoder ones. The {pbkdf2} and {sha256} hashed keys are
+// the hexadecimal encoding of the salt followed by the digest; the {scrypt}
+// ones are made of the parameters in hexadecimal, and of the salt and the
+// digest in base64. As Spring splits them by its configured salt length, the
+// salt is of 8 bytes for {pbkdf2} and {sha256}, as in Spring Security 5, and of
+// 16 bytes for {pbkdf2@SpringSecurity_v5_8}. {scrypt} parameters which need
+// more than 1 GiB of memory are rejected.
+//
+// As {noop} keys are stored in plain text, they are refused by the Crypter
+// returned by New; use NewWithNoop to accept them.
+package spring_crypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"hash"
+	"math/bits"
+	"strconv"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/argon2_crypt"
+	"github.com/GehirnInc/crypt/bcrypt_crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.SPRING, New,
+		MagicPrefixBcrypt, MagicPrefixPBKDF2, MagicPrefixPBKDF2V58,
+		MagicPrefixSHA256, MagicPrefixScrypt, MagicPrefixScryptV58,
+		MagicPrefixArgon2, MagicPrefixArgon2V58, MagicPrefixNoop)
+}
+
+const (
+	MagicPrefix          = MagicPrefixBcrypt
+	MagicPrefixBcrypt    = "{bcrypt}"
+	MagicPrefixPBKDF2    = "{pbkdf2}"
+	MagicPrefixPBKDF2V58 = "{pbkdf2@SpringSecurity_v5_8}"
+	MagicPrefixSHA256    = "{sha256}"
+	MagicPrefixScrypt    = "{scrypt}"
+	MagicPrefixScryptV58 = "{scrypt@SpringSecurity_v5_8}"
+	MagicPrefixArgon2    = "{argon2}"
+	MagicPrefixArgon2V58 = "{argon2@SpringSecurity_v5_8}"
+	MagicPrefixNoop      = "{noop}"
+	BcryptRoundsDefault  = 10
+	SHA256Rounds         = 1024
+	SHA256SaltLen        = 8 // in bytes
+	HashLen              = 32
+)
+
+var ErrNoopDisabled = errors.New("spring_crypt: {noop} keys are disabled")
+
+// pbkdf2Encoder holds the fixed parameters of a Pbkdf2PasswordEncoder.
+type pbkdf2Encoder struct {
+	hash    func() hash.Hash
+	rounds  int
+	saltLen int
+}
+
+var pbkdf2Encoders = map[string]pbkdf2Encoder{
+	MagicPrefixPBKDF2:    {sha1.New, 185000, 8},
+	MagicPrefixPBKDF2V58: {sha256.New, 310000, 16},
+}
+
+// scryptEncoder holds the default parameters of a SCryptPasswordEncoder.
+type scryptEncoder struct {
+	N, r, p int
+	saltLen int
+}
+
+var scryptEncoders = map[string]scryptEncoder{
+	MagicPrefixScrypt:    {16384, 8, 1, 64},
+	MagicPrefixScryptV58: {65536, 8, 1, 16},
+}
+
+var argon2Params = map[string]argon2_crypt.Params{
+	MagicPrefixArgon2:    {Memory: 1 << 12, Time: 3, Threads: 1},
+	MagicPrefixArgon2V58: {Memory: 1 << 14, Time: 2, Threads: 1},
+}
+
+type crypter struct {
+	Salt      common.Salt
+	allowNoop bool
+}
+
+// New returns a new crypt.Crypter computing the Spring Security password
+// hashing, which refuses {noop} keys. The magic prefix of its salt selects the
+// encoder used when no salt is given.
+func New() crypt.Crypter {
+	return &crypter{
+		Salt: common.Salt{MagicPrefix: []byte(MagicPrefix)},
+	}
+}
+
+// NewWithNoop is like New, but the returned crypt.Crypter accepts {noop} keys,
+// which are stored in plain text.
+func NewWithNoop() crypt.Crypter {
+	return &crypter{
+		Salt:      common.Salt{MagicPrefix: []byte(MagicPrefix)},
+		allowNoop: true,
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		salt = c.Salt.MagicPrefix
+	}
+	tag, rest, err := internal.SplitTag(salt)
+	if err != nil {
+		return "", err
+	}
+	id := string(tag)
+
+	var hashed string
+	switch id {
+	case MagicPrefixBcrypt:
+		if len(rest) == 0 {
+			rest = generateBcryptSalt()
+		}
+		hashed, err = bcrypt_crypt.New().Generate(key, rest)
+	case MagicPrefixPBKDF2, MagicPrefixPBKDF2V58:
+		hashed, err = generatePBKDF2(key, rest, pbkdf2Encoders[id])
+	case MagicPrefixSHA256:
+		hashed, err = generateSHA256(key, rest)
+	case MagicPrefixScrypt, MagicPrefixScryptV58:
+		hashed, err = generateScrypt(key, rest, scryptEncoders[id])
+	case MagicPrefixArgon2, MagicPrefixArgon2V58:
+		if len(rest) == 0 {
+			if rest, err = argon2_crypt.GenerateSalt(argon2_crypt.MagicPrefixID, argon2Params[id]); err != nil {
+				return "", err
+			}
+		}
+		hashed, err = argon2_crypt.New().Generate(key, rest)
+	case MagicPrefixNoop:
+		if !c.allowNoop {
+			return "", ErrNoopDisabled
+		}
+		hashed = string(key)
+	default:
+		return "", common.ErrSaltPrefix
+	}
+	if err != nil {
+		return "", err
+	}
+	return id + hashed, nil
+}
+
+func generateBcryptSalt() []byte {
+	s := common.Salt{
+		MagicPrefix: []byte(bcrypt_crypt.MagicPrefix2a),
+		SaltLenMax:  bcrypt_crypt.SaltLenMax,
+		RoundsMin:   bcrypt_crypt.RoundsMin,
+		RoundsMax:   bcrypt_crypt.RoundsMax,
+	}
+	return s.GenerateWCost(BcryptRoundsDefault)
+}
+
+func generatePBKDF2(key, setting []byte, e pbkdf2Encoder) (string, error) {
+	salt, err := decodeHexSalt(setting, e.saltLen)
+	if err != nil {
+		return "", err
+	}
+	sum := pbkdf2.Key(key, salt, e.rounds, HashLen, e.hash)
+	return pbkdf2.Hex.EncodeToString(append(salt, sum...)), nil
+}
+
+func generateSHA256(key, setting []byte) (string, error) {
+	salt, err := decodeHexSalt(setting, SHA256SaltLen)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write(salt)
+	h.Write(key)
+	sum := h.Sum(nil)
+	for i := 1; i < SHA256Rounds; i++ {
+		h.Reset()
+		h.Write(sum)
+		sum = h.Sum(sum[:0])
+	}
+	return pbkdf2.Hex.EncodeToString(append(salt, sum...)), nil
+}
+
+// decodeHexSalt returns the salt of the hexadecimal setting, which is either a
+// hashed key or a salt alone. As in Spring, the salt is of saltLen bytes, and
+// any other length is rejected. An empty setting gets a random salt.
+func decodeHexSalt(setting []byte, saltLen int) ([]byte, error) {
+	if len(setting) == 0 {
+		salt := make([]byte, saltLen)
+		rand.Read(salt)
+		return salt, nil
+	}
+
+	raw, err := pbkdf2.Hex.DecodeString(string(setting))
+	if err != nil {
+		return nil, common.ErrSaltFormat
+	}
+	switch len(raw) {
+	case saltLen:
+		return raw, nil
+	case saltLen + HashLen:
+		return raw[:saltLen], nil
+	}
+	return nil, common.ErrSaltFormat
+}
+
+func generateScrypt(key, setting []byte, e scryptEncoder) (string, error) {
+	if len(setting) == 0 {
+		salt := make([]byte, e.saltLen)
+		rand.Read(salt)
+		setting = encodeScryptParams(e.N, e.r, e.p)
+		setting = append(setting, '$')
+		setting = append(setting, pbkdf2.Base64.EncodeToString(salt)...)
+	}
+	N, r, p, salt, keyLen, err := decodeScrypt(setting)
+	if err != nil {
+		return "", err
+	}
+
+	sum, err := scrypt.Key(key, salt, N, r, p, keyLen)
+	if err != nil {
+		return "", common.ErrSaltRounds
+	}
+
+	buf := bytes.Buffer{}
+	buf.Write(encodeScryptParams(N, r, p))
+	buf.WriteByte('$')
+	buf.WriteString(pbkdf2.Base64.EncodeToString(salt))
+	buf.WriteByte('$')
+	buf.WriteString(pbkdf2.Base64.EncodeToString(sum))
+	return buf.String(), nil
+}
+
+func encodeScryptParams(N, r, p int) []byte {
+	params := uint64(bits.TrailingZeros(uint(N)))<<16 | uint64(r)<<8 | uint64(p)
+	return append([]byte{'$'}, strconv.FormatUint(params, 16)...)
+}
+
+// decodeScrypt returns the scrypt parameters, the salt and the length of the
+// digest of a {scrypt} setting.
+func decodeScrypt(setting []byte) (N, r, p int, salt []byte, keyLen int, err error) {
+	fields := bytes.Split(setting, []byte{'$'})
+	if len(fields) < 3 || len(fields) > 4 || len(fields[0]) != 0 {
+		err = common.ErrSaltFormat
+		return
+	}
+
+	params, perr := strconv.ParseUint(string(fields[1]), 16, 32)
+	logN := params >> 16
+	r, p = int(params>>8&0xff), int(params&0xff)
+	if perr != nil || logN < 1 || logN > 30 || r < 1 || p < 1 ||
+		!internal.ScryptParamsOK(1<<logN, uint64(r), uint64(p)) {
+		err = common.ErrSaltRounds
+		return
+	}
+	N = 1 << logN
+
+	if salt, err = pbkdf2.Base64.DecodeString(string(fields[2])); err != nil {
+		err = common.ErrSaltFormat
+		return
+	}
+	keyLen = HashLen
+	if len(fields) == 4 {
+		sum, derr := pbkdf2.Base64.DecodeString(string(fields[3]))
+		if derr != nil || len(sum) == 0 {
+			err = common.ErrSaltFormat
+			return
+		}
+		keyLen = len(sum)
+	}
+	return
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the cost of the wrapped hashed key for bcrypt and Argon2, the
+// base-2 logarithm of N for scrypt, the fixed number of iterations for
+// PBKDF2 and SHA-256, and 0 for {noop}.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	tag, rest, err := internal.SplitTag([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	id := string(tag)
+
+	switch id {
+	case MagicPrefixBcrypt:
+		return bcrypt_crypt.New().Cost(string(rest))
+	case MagicPrefixPBKDF2, MagicPrefixPBKDF2V58:
+		return pbkdf2Encoders[id].rounds, nil
+	case MagicPrefixSHA256:
+		return SHA256Rounds, nil
+	case MagicPrefixScrypt, MagicPrefixScryptV58:
+		N, _, _, _, _, err := decodeScrypt(rest)
+		if err != nil {
+			return 0, err
+		}
+		return bits.TrailingZeros(uint(N)), nil
+	case MagicPrefixArgon2, MagicPrefixArgon2V58:
+		return argon2_crypt.New().Cost(string(rest))
+	case MagicPrefixNoop:
+		return 0, nil
+	}
+	return 0, common.ErrSaltPrefix
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
@@ -0,0 +1,203 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package spring_crypt
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var springCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("{bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG"),
+			[]byte("password"),
+			"{bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG",
+			10,
+		},
+		{
+			[]byte("{pbkdf2}5d923b44a6d129f3ddf3e3c8d29412723dcbde72445e8ef6bf3b508fbf17fa4ed4d6b99ca763d8dc"),
+			[]byte("password"),
+			"{pbkdf2}5d923b44a6d129f3ddf3e3c8d29412723dcbde72445e8ef6bf3b508fbf17fa4ed4d6b99ca763d8dc",
+			185000,
+		},
+		{
+			[]byte("{pbkdf2}0001020304050607"),
+			[]byte("password"),
+			"{pbkdf2}0001020304050607c18c42a363543f68645d57859f952d9e5b74a5302ef4c2199584b3b948016b76",
+			185000,
+		},
+		{
+			[]byte("{pbkdf2@SpringSecurity_v5_8}000102030405060708090a0b0c0d0e0f"),
+			[]byte("password"),
+			"{pbkdf2@SpringSecurity_v5_8}000102030405060708090a0b0c0d0e0fe0f65a4bf6716253d2d10a7a4b18f35cd4baf31ff031a187cd0091674905482d",
+			310000,
+		},
+		{
+			[]byte("{sha256}97cde38028ad898ebc02e690819fa220e88c62e0699403e94fff291cfffaf8410849f27605abcbc0"),
+			[]byte("password"),
+			"{sha256}97cde38028ad898ebc02e690819fa220e88c62e0699403e94fff291cfffaf8410849f27605abcbc0",
+			SHA256Rounds,
+		},
+		{
+			[]byte("{scrypt}$e0801$8bWJaSu2IKSn9Z9kM+TPXfOc/9bdYSrN1oD9qfVThWEwdRTnO7re7Ei+fUZRJ68k9lTyuTeUp4of4g24hHnazw==$OAOec05+bXxvuu/1qZ6NUR+xQYvYv7BeL1QxwRpY5Pc="),
+			[]byte("password"),
+			"{scrypt}$e0801$8bWJaSu2IKSn9Z9kM+TPXfOc/9bdYSrN1oD9qfVThWEwdRTnO7re7Ei+fUZRJ68k9lTyuTeUp4of4g24hHnazw==$OAOec05+bXxvuu/1qZ6NUR+xQYvYv7BeL1QxwRpY5Pc=",
+			14,
+		},
+		{
+			[]byte("{scrypt@SpringSecurity_v5_8}$a0801$AAECAwQFBgcICQoLDA0ODw=="),
+			[]byte("password"),
+			"{scrypt@SpringSecurity_v5_8}$a0801$AAECAwQFBgcICQoLDA0ODw==$OnwHgqTb31Q6zXxSL+hT2bNKu4ryelxll0iM3yKBQLU=",
+			10,
+		},
+		{
+			[]byte("{argon2}$argon2id$v=19$m=256,t=2,p=2$c2Vhc2FsdHNlYXNhbHQ"),
+			[]byte("lètmein"),
+			"{argon2}$argon2id$v=19$m=256,t=2,p=2$c2Vhc2FsdHNlYXNhbHQ$SfYJ1eTiBQ/e+EIRRtSx5ye7QF2DaCQWOS4F4yaQCzA",
+			2,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := springCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := springCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.",
+		"{bcrypt$2a$10$dXJ3SW6G7P50lGmMkkmwe.",
+		"{BCRYPT}$2a$10$dXJ3SW6G7P50lGmMkkmwe.",
+		"{MD5}{salt}hash",
+		"{bcrypt}$2a$10$dXJ3SW6G7P50l",
+		"{pbkdf2}00010203zz",
+		"{pbkdf2}000102030405060708",
+		"{pbkdf2}000102030405060708090a0b0c0d0e0f",
+		"{pbkdf2}5d923b44a6d129f3ddf3e3c8d29412723dcbde72445e8ef6bf3b508fbf17fa4ed4d6b99ca763d8dc00",
+		"{pbkdf2@SpringSecurity_v5_8}0001020304050607",
+		"{sha256}00010203040506",
+		"{sha256}0001020304050607x",
+		"{scrypt}e0801$AAECAwQFBgcICQoLDA0ODw==",
+		"{scrypt}$z0801$AAECAwQFBgcICQoLDA0ODw==",
+		"{scrypt}$e0801$AAECAwQFBgcICQoLDA0ODw",
+		"{scrypt}$1e0801$AAECAwQFBgcICQoLDA0ODw==",
+		"{scrypt}$14ff01$AAECAwQFBgcICQoLDA0ODw==",
+		"{argon2}$argon2x$v=19$m=256,t=2,p=2$c2Vhc2FsdHNlYXNhbHQ",
+	}
+	for i, d := range data {
+		if _, err := springCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestGenerateHexSaltLen(t *testing.T) {
+	data := []struct {
+		prefix  string
+		saltLen int
+	}{
+		{MagicPrefixPBKDF2, 8},
+		{MagicPrefixPBKDF2V58, 16},
+		{MagicPrefixSHA256, SHA256SaltLen},
+	}
+	for i, d := range data {
+		hash, err := springCrypt.Generate([]byte("password"), []byte(d.prefix))
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := hex.DecodeString(hash[len(d.prefix):])
+		if err != nil || len(raw) != d.saltLen+HashLen {
+			t.Errorf("Test %d failed: unexpected hash %s", i, hash)
+		}
+	}
+}
+
+func TestNoop(t *testing.T) {
+	if _, err := springCrypt.Generate([]byte("password"), []byte(MagicPrefixNoop)); err != ErrNoopDisabled {
+		t.Errorf("Expected %v, got %v", ErrNoopDisabled, err)
+	}
+	if err := springCrypt.Verify("{noop}password", []byte("password")); err != ErrNoopDisabled {
+		t.Errorf("Expected %v, got %v", ErrNoopDisabled, err)
+	}
+	if err := crypt.NewFromHash("{noop}password").Verify("{noop}password", []byte("password")); err != ErrNoopDisabled {
+		t.Errorf("Expected %v, got %v", ErrNoopDisabled, err)
+	}
+
+	noopCrypt := NewWithNoop()
+	hash, err := noopCrypt.Generate([]byte("password"), []byte(MagicPrefixNoop))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hash != "{noop}password" {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = noopCrypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verify failed: %s", err)
+	}
+	if err = noopCrypt.Verify(hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+		t.Errorf("Wrong key was accepted")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of the Spring Security reference documentation, and of the
+	// "@SpringSecurity_v5_8" defaults computed with Python's hashlib and
+	// golang.org/x/crypto/argon2.
+	data := []string{
+		"{bcrypt}$2a$10$dXJ3SW6G7P50lGmMkkmwe.20cQQubK3.HZWzG3YB1tlRy.fqvM/BG",
+		"{pbkdf2}5d923b44a6d129f3ddf3e3c8d29412723dcbde72445e8ef6bf3b508fbf17fa4ed4d6b99ca763d8dc",
+		"{sha256}97cde38028ad898ebc02e690819fa220e88c62e0699403e94fff291cfffaf8410849f27605abcbc0",
+		"{scrypt}$e0801$8bWJaSu2IKSn9Z9kM+TPXfOc/9bdYSrN1oD9qfVThWEwdRTnO7re7Ei+fUZRJ68k9lTyuTeUp4of4g24hHnazw==$OAOec05+bXxvuu/1qZ6NUR+xQYvYv7BeL1QxwRpY5Pc=",
+		"{pbkdf2@SpringSecurity_v5_8}000102030405060708090a0b0c0d0e0fe0f65a4bf6716253d2d10a7a4b18f35cd4baf31ff031a187cd0091674905482d",
+		"{argon2@SpringSecurity_v5_8}$argon2id$v=19$m=16384,t=2,p=1$MDEyMzQ1Njc4OWFiY2RlZg$/T5LYAqt765T64Brg0XAKXa8IAjKcrkH2L/nlKN0Ghg",
+	}
+	for i, d := range data {
+		if err := springCrypt.Verify(d, []byte("password")); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err := springCrypt.Verify(d, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	settings := []string{"", "{pbkdf2}", "{sha256}", "{scrypt}$a0801$AAECAwQFBgcICQoLDA0ODw==", "{argon2}"}
+	for i, s := range settings {
+		hash, err := springCrypt.Generate([]byte("password"), []byte(s))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(hash, "{") || s != "" && !strings.HasPrefix(hash, s[:strings.IndexByte(s, '}')+1]) {
+			t.Errorf("Test %d failed: unexpected hash %s", i, hash)
+		}
+		if err = springCrypt.Verify(hash, []byte("password")); err != nil {
+			t.Errorf("Test %d failed: %s", i, hash)
+		}
+	}
+}
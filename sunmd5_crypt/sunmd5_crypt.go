@@ -0,0 +1,225 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package sunmd5_crypt implements the MD5-based crypt(3) function designed by
+// Alec Muffett for Solaris, not to be confused with the MD5-crypt of FreeBSD.
+//
+// The setting is made of the magic prefix, an optional count of additional
+// rounds, and the salt:
+//
+//	$md5$salt$
+//	$md5,rounds=5000$salt$
+//
+// Solaris also reads the count of rounds after a "$", as in
+// "$md5$rounds=5000$salt$", and so does this package.
+//
+// The whole setting is hashed along with the key. Solaris puts a "$" between
+// the setting above and the checksum, and so hashes the "$" which terminates
+// the salt; hashed keys which have a single "$" before the checksum are
+// supported as well, and the terminating "$" is not hashed for them.
+package sunmd5_crypt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/subtle"
+	"strconv"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.SUNMD5, New, MagicPrefix, MagicPrefixRounds)
+}
+
+const (
+	MagicPrefix       = "$md5$"
+	MagicPrefixRounds = "$md5,"
+	SaltLenMin        = 0
+	SaltLenMax        = 8
+	RoundsMin         = 1                       // additional rounds, when specified
+	RoundsMax         = 1<<31 - 1 - BasicRounds // the total must fit in int
+	RoundsDefault     = 5000
+	BasicRounds       = 4096
+)
+
+const (
+	roundsPrefix       = "$md5,rounds="
+	roundsPrefixDollar = "$md5$rounds="
+	alphabet           = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+)
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the Sun MD5 password hashing.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		salt = c.generateSalt()
+	}
+	setting, rounds, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+
+	h := md5.New()
+	h.Write(key)
+	h.Write(setting)
+	sum := h.Sum(nil)
+
+	var digits []byte
+	for round := 0; round < BasicRounds+rounds; round++ {
+		h.Reset()
+		h.Write(sum)
+		if coinFlip(sum, round) {
+			h.Write([]byte(hamlet))
+		}
+		digits = strconv.AppendInt(digits[:0], int64(round), 10)
+		h.Write(digits)
+		sum = h.Sum(sum[:0])
+	}
+
+	// The bytes are transposed as by MD5-crypt.
+	out := make([]byte, 0, len(setting)+1+22)
+	out = append(out, setting...)
+	out = append(out, '$')
+	out = append(out, common.Base64_24Bit([]byte{
+		sum[12], sum[6], sum[0],
+		sum[13], sum[7], sum[1],
+		sum[14], sum[8], sum[2],
+		sum[15], sum[9], sum[3],
+		sum[5], sum[10], sum[4],
+		sum[11],
+	})...)
+	return string(out), nil
+}
+
+// coinFlip decides from the digest of the previous round whether the passage
+// of Hamlet is hashed in the given round.
+func coinFlip(sum []byte, round int) bool {
+	bit := func(n int) int {
+		n %= 128
+		return int(sum[n/8]>>uint(n%8)) & 1
+	}
+
+	// Two bit indices are built from bits of the digest chosen through two
+	// levels of indirection.
+	var a, b int
+	for i := 0; i < 16; i++ {
+		next := int(sum[(i+3)&15])
+		index4 := int(sum[i]) >> uint(next%5) & 0x0f
+		shift7 := next >> uint(sum[i]%8) & 1
+		index7 := int(sum[index4]) >> uint(shift7) & 0x7f
+		if i < 8 {
+			a |= bit(index7) << uint(i)
+		} else {
+			b |= bit(index7) << uint(i-8)
+		}
+	}
+	a >>= uint(bit(round))
+	b >>= uint(bit(round + 64))
+
+	return bit(a)^bit(b) == 1
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the total number of rounds, that is BasicRounds plus the
+// additional rounds of the hashed key.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	_, rounds, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return BasicRounds + rounds, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+func (c *crypter) generateSalt() []byte {
+	salt := c.Salt.Generate(c.Salt.SaltLenMax)[len(c.Salt.MagicPrefix):]
+
+	buf := bytes.Buffer{}
+	if c.Salt.RoundsDefault > 0 {
+		buf.WriteString(roundsPrefix)
+		buf.WriteString(strconv.Itoa(c.Salt.RoundsDefault))
+		buf.WriteByte('$')
+	} else {
+		buf.Write(c.Salt.MagicPrefix)
+	}
+	buf.Write(salt)
+	buf.WriteByte('$')
+	return buf.Bytes()
+}
+
+// decode returns the part of raw which is hashed with the key, and the number
+// of additional rounds.
+func (c *crypter) decode(raw []byte) (setting []byte, rounds int, err error) {
+	var rest []byte
+	switch {
+	case bytes.HasPrefix(raw, []byte(roundsPrefix)),
+		bytes.HasPrefix(raw, []byte(roundsPrefixDollar)):
+		rest = raw[len(roundsPrefix):]
+		i := bytes.IndexByte(rest, '$')
+		if i < 0 {
+			return nil, 0, common.ErrSaltFormat
+		}
+		// Leading zeros are not allowed.
+		n, err := strconv.ParseUint(string(rest[:i]), 10, 32)
+		if err != nil || rest[0] == '0' || n < uint64(c.Salt.RoundsMin) || n > uint64(c.Salt.RoundsMax) {
+			return nil, 0, common.ErrSaltRounds
+		}
+		rounds = int(n)
+		rest = rest[i+1:]
+
+	case bytes.HasPrefix(raw, c.Salt.MagicPrefix):
+		rest = raw[len(c.Salt.MagicPrefix):]
+
+	default:
+		return nil, 0, common.ErrSaltPrefix
+	}
+
+	saltLen := bytes.IndexByte(rest, '$')
+	if saltLen < 0 {
+		saltLen = len(rest)
+	}
+	if saltLen < c.Salt.SaltLenMin {
+		return nil, 0, common.ErrSaltFormat
+	}
+	for _, ch := range rest[:saltLen] {
+		if bytes.IndexByte([]byte(alphabet), ch) < 0 {
+			return nil, 0, common.ErrSaltFormat
+		}
+	}
+
+	end := len(raw) - len(rest) + saltLen
+	if saltLen+1 < len(rest) && rest[saltLen+1] == '$' || saltLen+1 == len(rest) {
+		// The "$" which terminates the salt is hashed when it is followed by
+		// another one, or when it ends a setting.
+		end++
+	}
+	return raw[:end], rounds, nil
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package sunmd5_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var sunmd5Crypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("$md5$zrdhpMlZ$$"),
+			[]byte("Gpcs3_adm"),
+			"$md5$zrdhpMlZ$$wBvMOEqbSjU.hu5T2VEP01",
+			4096,
+		},
+		{
+			[]byte("$md5$salt$"),
+			[]byte("password"),
+			"$md5$salt$$wzeAbcD.IeWmdgZ1DkhxH/",
+			4096,
+		},
+		{
+			[]byte("$md5$salt$$wzeAbcD.IeWmdgZ1DkhxH/"),
+			[]byte("password"),
+			"$md5$salt$$wzeAbcD.IeWmdgZ1DkhxH/",
+			4096,
+		},
+		{
+			// The "$" terminating the salt is not hashed.
+			[]byte("$md5$salt$yNhmYjR7AIsFMOu2TS0J.0"),
+			[]byte("password"),
+			"$md5$salt$yNhmYjR7AIsFMOu2TS0J.0",
+			4096,
+		},
+		{
+			[]byte("$md5$salt"),
+			[]byte("password"),
+			"$md5$salt$yNhmYjR7AIsFMOu2TS0J.0",
+			4096,
+		},
+		{
+			[]byte("$md5,rounds=1000$salt$"),
+			[]byte("password"),
+			"$md5,rounds=1000$salt$$QBovfH9OczxPFo9YixlBi/",
+			5096,
+		},
+		{
+			[]byte("$md5,rounds=5$sa$t$"),
+			[]byte("password"),
+			"$md5,rounds=5$sa$RC3aUfEmq5Xh5RC3nrZV/.",
+			4101,
+		},
+		{
+			[]byte("$md5$rounds=10$saltsalt$"),
+			[]byte("password"),
+			"$md5$rounds=10$saltsalt$$D5RfrUI71mMFz.P60P5Th/",
+			4106,
+		},
+		{
+			[]byte("$md5$rounds=10$saltsalt"),
+			[]byte("password"),
+			"$md5$rounds=10$saltsalt$dGyQk8PMlXhjuNe34HY/.0",
+			4106,
+		},
+		{
+			[]byte("$md5$$"),
+			[]byte("password"),
+			"$md5$$$r5ORZ5Jo1qbP6jE7mTCRo1",
+			4096,
+		},
+		{
+			[]byte("$md5$"),
+			[]byte("password"),
+			"$md5$$llcQt2H/OVQCpQFhfcdcD.",
+			4096,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := sunmd5Crypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := sunmd5Crypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"$md6$salt$",
+		"$md5,rounds=0$salt$",
+		"$md5,rounds=01$salt$",
+		"$md5,rounds=$salt$",
+		"$md5,rounds=2147479552$salt$",
+		"$md5,rounds=1000,x$salt$",
+		"$md5,rounds=1000",
+		"$md5$rounds=0$salt$",
+		"$md5$rounds=x$salt$",
+		"$md5$rounds=10",
+		"$md5$sa:t$",
+	}
+	for i, d := range data {
+		if _, err := sunmd5Crypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestCostMax(t *testing.T) {
+	cost, err := sunmd5Crypt.Cost("$md5,rounds=2147479551$salt$")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cost != 1<<31-1 {
+		t.Errorf("Expected: %d, got: %d", 1<<31-1, cost)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys of libxcrypt.
+	data := []struct {
+		hash string
+		key  []byte
+	}{
+		{"$md5$zrdhpMlZ$$wBvMOEqbSjU.hu5T2VEP01", []byte("Gpcs3_adm")},
+		{"$md5$zrdhpMlZ$zJm70qSN4nMRSx.qe8PXQ1", []byte("Gpcs3_adm")},
+		{"$md5,rounds=5000$kCaBbUJm$$UwxQRWwz/u.tR8IaX8slq.", []byte("aa12345678")},
+		{"$md5$rounds=904$Vc3VgyFx44iS8.Yu$$GhutL8i2kA8qJqC2osUuO0", []byte("passwd")},
+		{"$md5$saltstring$$fsg5ObyJIRoWasGYfynFz1", []byte("")},
+	}
+	for i, d := range data {
+		if err := sunmd5Crypt.Verify(d.hash, d.key); err != nil {
+			t.Errorf("Test %d failed: %s", i, d.key)
+		}
+		if err := sunmd5Crypt.Verify(d.hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	hash, err := sunmd5Crypt.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "$md5,rounds=5000$") || strings.Count(hash, "$$") != 1 {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = sunmd5Crypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verification failed: %s", hash)
+	}
+}
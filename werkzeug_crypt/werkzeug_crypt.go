@@ -0,0 +1,257 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package werkzeug_crypt implements the password hashing of Werkzeug, used by
+// Flask applications through generate_password_hash. A hashed key is made of
+// the method and its parameters separated by ":", the salt, and the digest in
+// hexadecimal, separated by "$":
+//
+//	pbkdf2:sha256:600000$bCxsN5k1ArVMiPza$91cc0457d13e921a6d6ffc1d7fd02a699a0d6428d6e089016079b1f4b2012dfe
+//	scrypt:32768:8:1$bCxsN5k1ArVMiPza$e0c806092e687ac00f60c9057da8d44e...
+//
+// The hash functions of PBKDF2 are those of Python's hashlib which Go
+// provides: md5, sha1, sha224, sha256, sha384, sha512, sha512_224,
+// sha512_256, sha3_224, sha3_256, sha3_384, sha3_512, and blake2b and blake2s
+// with their default digest sizes. Other methods are rejected.
+//
+// The salt is used as is. The parameters omitted from the method take the
+// defaults of Werkzeug 3.1, and the method is reproduced as given. Like
+// Werkzeug, which bounds the memory of hashlib.scrypt, scrypt parameters which
+// need more than 1 GiB are rejected.
+package werkzeug_crypt
+
+import (
+	"bytes"
+	"crypto/md5"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"hash"
+	"strconv"
+
+	"golang.org/x/crypto/blake2b"
+	"golang.org/x/crypto/blake2s"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/crypto/sha3"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.WERKZEUG, New, MagicPrefixPBKDF2, MagicPrefixScrypt)
+}
+
+const (
+	MagicPrefix       = MagicPrefixScrypt
+	MagicPrefixPBKDF2 = "pbkdf2:"
+	MagicPrefixScrypt = "scrypt:"
+
+	SaltLenMin    = 1
+	SaltLenMax    = 16 // as Werkzeug generates
+	RoundsMin     = 1
+	RoundsMax     = 1<<31 - 1
+	RoundsDefault = 1000000 // PBKDF2 iterations
+
+	HashDefault    = "sha256"
+	ScryptNDefault = 1 << 15
+	ScryptRDefault = 8
+	ScryptPDefault = 1
+	ScryptHashLen  = 64
+)
+
+// pbkdf2Hashes maps the names of hashlib to the hash functions of PBKDF2.
+var pbkdf2Hashes = map[string]func() hash.Hash{
+	"md5":        md5.New,
+	"sha1":       sha1.New,
+	"sha224":     sha256.New224,
+	"sha256":     sha256.New,
+	"sha384":     sha512.New384,
+	"sha512":     sha512.New,
+	"sha512_224": sha512.New512_224,
+	"sha512_256": sha512.New512_256,
+	"sha3_224":   sha3.New224,
+	"sha3_256":   sha3.New256,
+	"sha3_384":   sha3.New384,
+	"sha3_512":   sha3.New512,
+	"blake2b":    newBlake2b,
+	"blake2s":    newBlake2s,
+}
+
+func newBlake2b() hash.Hash {
+	h, _ := blake2b.New512(nil)
+	return h
+}
+
+func newBlake2s() hash.Hash {
+	h, _ := blake2s.New256(nil)
+	return h
+}
+
+const saltAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the Werkzeug password hashing. The
+// magic prefix of its salt selects the method used when no salt is given.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix:   []byte(MagicPrefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		},
+	}
+}
+
+// method is a decoded method field.
+type method struct {
+	pbkdf2  bool
+	hash    func() hash.Hash
+	rounds  int
+	N, r, p int
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = c.generateSalt(); err != nil {
+			return "", err
+		}
+	}
+	fields := bytes.Split(salt, []byte{'$'})
+	if len(fields) < 2 || len(fields) > 3 {
+		return "", common.ErrSaltFormat
+	}
+	m, err := c.decodeMethod(fields[0])
+	if err != nil {
+		return "", err
+	}
+	if len(fields[1]) < c.Salt.SaltLenMin {
+		return "", common.ErrSaltFormat
+	}
+
+	var sum []byte
+	if m.pbkdf2 {
+		sum = pbkdf2.Key(key, fields[1], m.rounds, m.hash().Size(), m.hash)
+	} else if sum, err = scrypt.Key(key, fields[1], m.N, m.r, m.p, ScryptHashLen); err != nil {
+		return "", common.ErrSaltRounds
+	}
+
+	buf := bytes.Buffer{}
+	buf.Write(fields[0])
+	buf.WriteByte('$')
+	buf.Write(fields[1])
+	buf.WriteByte('$')
+	buf.WriteString(pbkdf2.Hex.EncodeToString(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the number of iterations for PBKDF2, and N for scrypt.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	i := bytes.IndexByte([]byte(hashedKey), '$')
+	if i < 0 {
+		return 0, common.ErrSaltFormat
+	}
+	m, err := c.decodeMethod([]byte(hashedKey[:i]))
+	if err != nil {
+		return 0, err
+	}
+	if m.pbkdf2 {
+		return m.rounds, nil
+	}
+	return m.N, nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// generateSalt returns a setting for the method of the magic prefix, with all
+// its parameters and a random salt.
+func (c *crypter) generateSalt() ([]byte, error) {
+	buf := bytes.Buffer{}
+	switch string(c.Salt.MagicPrefix) {
+	case MagicPrefixPBKDF2:
+		buf.WriteString(MagicPrefixPBKDF2)
+		buf.WriteString(HashDefault)
+		buf.WriteByte(':')
+		buf.WriteString(strconv.Itoa(c.Salt.RoundsDefault))
+	case MagicPrefixScrypt:
+		buf.WriteString(MagicPrefixScrypt)
+		buf.WriteString(strconv.Itoa(ScryptNDefault))
+		buf.WriteByte(':')
+		buf.WriteString(strconv.Itoa(ScryptRDefault))
+		buf.WriteByte(':')
+		buf.WriteString(strconv.Itoa(ScryptPDefault))
+	default:
+		return nil, common.ErrSaltPrefix
+	}
+	buf.WriteByte('$')
+	buf.Write(internal.RandomString(saltAlphabet, c.Salt.SaltLenMax))
+	return buf.Bytes(), nil
+}
+
+// decodeMethod decodes the method field, which is either "pbkdf2", optionally
+// followed by the hash function and the number of iterations, or "scrypt",
+// optionally followed by N, r and p.
+func (c *crypter) decodeMethod(raw []byte) (m method, err error) {
+	args := bytes.Split(raw, []byte{':'})
+	switch string(args[0]) {
+	case MagicPrefixPBKDF2[:len(MagicPrefixPBKDF2)-1]:
+		if len(args) > 3 {
+			return m, common.ErrSaltFormat
+		}
+		m.pbkdf2 = true
+		m.hash, m.rounds = pbkdf2Hashes[HashDefault], c.Salt.RoundsDefault
+		if len(args) > 1 {
+			var ok bool
+			if m.hash, ok = pbkdf2Hashes[string(args[1])]; !ok {
+				return m, common.ErrSaltFormat
+			}
+		}
+		if len(args) > 2 {
+			var ok bool
+			if m.rounds, ok = internal.ParseInt(args[2]); !ok || m.rounds < c.Salt.RoundsMin || m.rounds > c.Salt.RoundsMax {
+				return m, common.ErrSaltRounds
+			}
+		}
+
+	case MagicPrefixScrypt[:len(MagicPrefixScrypt)-1]:
+		m.N, m.r, m.p = ScryptNDefault, ScryptRDefault, ScryptPDefault
+		if len(args) == 1 {
+			break
+		}
+		if len(args) != 4 {
+			return m, common.ErrSaltFormat
+		}
+		var ok1, ok2, ok3 bool
+		m.N, ok1 = internal.ParseInt(args[1])
+		m.r, ok2 = internal.ParseInt(args[2])
+		m.p, ok3 = internal.ParseInt(args[3])
+		if !ok1 || !ok2 || !ok3 || m.N <= 1 || m.N&(m.N-1) != 0 || m.r < 1 || m.p < 1 ||
+			!internal.ScryptParamsOK(uint64(m.N), uint64(m.r), uint64(m.p)) {
+			return m, common.ErrSaltRounds
+		}
+
+	default:
+		return m, common.ErrSaltPrefix
+	}
+	return m, nil
+}
@@ -0,0 +1,186 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package werkzeug_crypt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+var werkzeugCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("pbkdf2:sha256:600000$bCxsN5k1ArVMiPza$91cc0457d13e921a6d6ffc1d7fd02a699a0d6428d6e089016079b1f4b2012dfe"),
+			[]byte("password"),
+			"pbkdf2:sha256:600000$bCxsN5k1ArVMiPza$91cc0457d13e921a6d6ffc1d7fd02a699a0d6428d6e089016079b1f4b2012dfe",
+			600000,
+		},
+		{
+			[]byte("pbkdf2:sha1:1000$salt"),
+			[]byte("password"),
+			"pbkdf2:sha1:1000$salt$6e88be8bad7eae9d9e10aa061224034fed48d03f",
+			1000,
+		},
+		{
+			[]byte("pbkdf2:sha512:1000$salt$"),
+			[]byte("password"),
+			"pbkdf2:sha512:1000$salt$afe6c5530785b6cc6b1c6453384731bd5ee432ee549fd42fb6695779ad8a1c5bf59de69c48f774efc4007d5298f9033c0241d5ab69305e7b64eceeb8d834cfec",
+			1000,
+		},
+		{
+			[]byte("pbkdf2:sha3_256:1000$bCxsN5k1ArVMiPza"),
+			[]byte("password"),
+			"pbkdf2:sha3_256:1000$bCxsN5k1ArVMiPza$f6dedc876ab233e350786db2f1d7f8164059400a89b7502a649db9770840ca4f",
+			1000,
+		},
+		{
+			[]byte("pbkdf2:sha512_224:1000$bCxsN5k1ArVMiPza"),
+			[]byte("password"),
+			"pbkdf2:sha512_224:1000$bCxsN5k1ArVMiPza$016e8195e8aca707543b3602063883745f3dc8b2c0592a23095ee802",
+			1000,
+		},
+		{
+			[]byte("pbkdf2:blake2b:1000$bCxsN5k1ArVMiPza"),
+			[]byte("password"),
+			"pbkdf2:blake2b:1000$bCxsN5k1ArVMiPza$f368ed206d89fb821859920efe97b2ee596e44caa4ffde43677c0a98d9c99d59552a80c0231ae1288b71a9b3bf045971c45c7a368df24411ab98a5ae90864a6f",
+			1000,
+		},
+		{
+			[]byte("pbkdf2:blake2s:1000$bCxsN5k1ArVMiPza"),
+			[]byte("password"),
+			"pbkdf2:blake2s:1000$bCxsN5k1ArVMiPza$a3dd0bd9c39337990cb83e31bafcac451f1fb9d75e65feb4402247eacd540729",
+			1000,
+		},
+		{
+			[]byte("pbkdf2:sha256$salt"),
+			[]byte("password"),
+			"pbkdf2:sha256$salt$505112a590be61ac9d3a235bf0a8eecea40e54652ec0e3c257c227c9aa5e664c",
+			RoundsDefault,
+		},
+		{
+			[]byte("scrypt:32768:8:1$bCxsN5k1ArVMiPza"),
+			[]byte("password"),
+			"scrypt:32768:8:1$bCxsN5k1ArVMiPza$e0c806092e687ac00f60c9057da8d44e4f1584e4f0611d613bc6951b86b4a005945e521569623de06bcb57cd797b5b07560ff884154b70b1704626b7d5821231",
+			32768,
+		},
+		{
+			[]byte("scrypt:1024:2:1$salt$"),
+			[]byte("password"),
+			"scrypt:1024:2:1$salt$6310506416afe402004a26cf4e2f40627489f539167a9ee95ab6ac29d6e1360610652c300b3f3587d6100d6e8aa490476edccbba41a57b8b1d881e997568e808",
+			1024,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := werkzeugCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := werkzeugCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"bcrypt:12$salt",
+		"pbkdf2:md4:1000$salt",
+		"pbkdf2:sha256:01000$salt",
+		"pbkdf2:sha256:1000:1$salt",
+		"pbkdf2:sha256:1000$$",
+		"pbkdf2:sha256:1000",
+		"scrypt:1000:8:1$salt",
+		"scrypt:1024:8$salt",
+		"scrypt:1024:8:1$sa$lt$hash",
+		"scrypt:1073741824:8:1$abc$00",
+		"scrypt:1048576:8:2$salt",
+		"scrypt:1024:0:1$salt",
+		"scrypt:1024:8:0$salt",
+	}
+	for i, d := range data {
+		if _, err := werkzeugCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestGenerateSalt(t *testing.T) {
+	data := []struct {
+		prefix string
+		want   string
+	}{
+		{MagicPrefixPBKDF2, "pbkdf2:sha256:1000000$"},
+		{MagicPrefixScrypt, "scrypt:32768:8:1$"},
+	}
+	for i, d := range data {
+		c := &crypter{}
+		c.SetSalt(common.Salt{
+			MagicPrefix:   []byte(d.prefix),
+			SaltLenMin:    SaltLenMin,
+			SaltLenMax:    SaltLenMax,
+			RoundsMin:     RoundsMin,
+			RoundsMax:     RoundsMax,
+			RoundsDefault: RoundsDefault,
+		})
+		salt, err := c.generateSalt()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasPrefix(string(salt), d.want) || len(salt) != len(d.want)+SaltLenMax {
+			t.Errorf("Test %d failed\nExpected: %s..., got: %s", i, d.want, salt)
+		}
+		if strings.Trim(string(salt[len(d.want):]), saltAlphabet) != "" {
+			t.Errorf("Test %d failed: unexpected salt %s", i, salt)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	// Hashed keys as Werkzeug computes them with Python's hashlib.
+	data := []string{
+		"pbkdf2:sha1:1000$Xr3JsaH8WQ2aDk5C$41ad48e65eb853d83e351843c53f8a9490079e4b",
+		"pbkdf2:sha512:1000$Xr3JsaH8WQ2aDk5C$de15bf3d21ce95b76509cbe0bec302ede756be442b6c6b21ab9e3b3511cc7220ad664c1869be754ff77ee450b5edc2a6bbb8d605095ec7d2d07fdc572a7f426e",
+		"scrypt:32768:8:1$Xr3JsaH8WQ2aDk5C$ab8d7dff295a0d5dacc379dd08690cd01a0e43314d678036e8c82246ad119fc17fe18100c55e588ff46681709aa905c74de7212aa9e55c1f02c2dc2ba8ef3b39",
+		"scrypt:1024:8:1$Xr3JsaH8WQ2aDk5C$16cd5f78a66c3ca374e35bf698c73c233f4a13076c4c16eb9627e801188cd4e669bb035046c502295094fdf2524239f863bc1d5827edc9e800c6dc2c1bf30aab",
+	}
+	for i, d := range data {
+		if err := werkzeugCrypt.Verify(d, []byte("secret")); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err := werkzeugCrypt.Verify(d, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+
+	hash, err := werkzeugCrypt.Generate([]byte("password"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(hash, "scrypt:32768:8:1$") {
+		t.Errorf("Unexpected hash %s", hash)
+	}
+	if err = werkzeugCrypt.Verify(hash, []byte("password")); err != nil {
+		t.Errorf("Verify failed: %s", err)
+	}
+}
@@ -0,0 +1,452 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package yescrypt
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+
+	"github.com/GehirnInc/crypt/internal"
+	"github.com/GehirnInc/crypt/pbkdf2"
+)
+
+// pwxform settings used by every yescrypt flavor in use, which are the only
+// ones supported here.
+const (
+	pwxSimple = 2
+	pwxGather = 4
+	pwxRounds = 6
+	sWidth    = 8
+
+	pwxBytes = pwxGather * pwxSimple * 8
+	pwxWords = pwxBytes / 4
+	sBytes   = 3 * (1 << sWidth) * pwxSimple * 8
+	sWords   = sBytes / 4
+	sMask    = ((1 << sWidth) - 1) * pwxSimple * 8
+)
+
+// flagPrehash marks the reduced-cost pass which prehashes the key when a lot
+// of memory is used. It is internal to the algorithm and never encoded.
+const flagPrehash = 0x10000000
+
+const maxInt = int(^uint(0) >> 1)
+
+var (
+	errParams      = errors.New("yescrypt: invalid parameters")
+	errParamsLarge = errors.New("yescrypt: parameters are too large")
+)
+
+// Key derives a key of keyLen bytes from the password and the salt, using the
+// given parameters.
+//
+// When params.Flags is zero, the result is the classic scrypt key derivation
+// function. Parameters which need more than 1 GiB of memory, 128*N*r bytes,
+// are rejected.
+func Key(password, salt []byte, params Params, keyLen int) ([]byte, error) {
+	flags, N, r, p, t := params.Flags, params.N, params.R, params.P, params.T
+
+	switch flags & flagModeMask {
+	case 0:
+		if flags != 0 || t != 0 {
+			return nil, errParams
+		}
+	case FlagWORM:
+		if flags != FlagWORM {
+			return nil, errParams
+		}
+	case FlagRW:
+		if flags != FlagsDefault {
+			return nil, errParams
+		}
+	default:
+		return nil, errParams
+	}
+	if N <= 1 || N&(N-1) != 0 || r < 1 || p < 1 {
+		return nil, errParams
+	}
+	if flags&FlagRW != 0 && N/uint64(p) <= 1 {
+		return nil, errParams
+	}
+	if uint64(r)*uint64(p) >= 1<<30 || N > 1<<32-1 ||
+		int(r) > maxInt/256/int(p) || N > uint64(maxInt/128/int(r)) ||
+		N > internal.ScryptMemoryMax/128/uint64(r) {
+		return nil, errParamsLarge
+	}
+
+	if flags&FlagRW != 0 && N/uint64(p) >= 0x100 && N/uint64(p)*uint64(r) >= 0x20000 {
+		dk := kdf(password, salt, flags|flagPrehash, N>>6, int(r), int(p), 0, 32)
+		defer clean(dk)
+		password = dk
+	}
+
+	return kdf(password, salt, flags, N, int(r), int(p), t, keyLen), nil
+}
+
+func kdf(password, salt []byte, flags uint32, N uint64, r, p int, t uint32, keyLen int) []byte {
+	var sha []byte
+	if flags != 0 {
+		prehash := []byte("yescrypt-prehash")
+		if flags&flagPrehash == 0 {
+			prehash = prehash[:8]
+		}
+		h := hmac.New(sha256.New, prehash)
+		h.Write(password)
+		sha = h.Sum(nil)
+		password = sha
+	}
+
+	b := pbkdf2.SHA256(password, salt, 1, 128*r*p)
+	if flags != 0 {
+		copy(sha, b)
+	}
+
+	s := 32 * r
+	xy := make([]uint32, 2*s)
+	if p == 1 || flags&FlagRW != 0 {
+		v := make([]uint32, int(N)*s)
+		smix(b, r, N, p, t, flags, v, xy, sha)
+	} else {
+		v := make([]uint32, int(N)*s)
+		for i := 0; i < p; i++ {
+			smix(b[128*r*i:], r, N, 1, t, flags, v, xy, nil)
+		}
+	}
+
+	dkLen := keyLen
+	if flags != 0 && dkLen < 32 {
+		dkLen = 32
+	}
+	dk := pbkdf2.SHA256(password, b, 1, dkLen)
+	clean32(xy)
+	clean(b)
+
+	// The final steps match those of SCRAM (RFC 5802), so that the costly
+	// computation above may be performed by the client.
+	if flags != 0 && flags&flagPrehash == 0 {
+		h := hmac.New(sha256.New, dk[:32])
+		h.Write([]byte("Client Key"))
+		clientKey := h.Sum(nil)
+		storedKey := sha256.Sum256(clientKey)
+		copy(dk, storedKey[:])
+	}
+	return dk[:keyLen]
+}
+
+// pwxformCtx holds the S-boxes of a pwxform instance.
+type pwxformCtx struct {
+	s          []uint32
+	s0, s1, s2 []uint32
+	w          int
+}
+
+// smix computes the second step of yescrypt, which is also scrypt's SMix when
+// flags is zero, on p consecutive blocks of 128*r bytes in b.
+func smix(b []byte, r int, N uint64, p int, t uint32, flags uint32, v, xy []uint32, sha []byte) {
+	s := 32 * r
+
+	nChunk := N / uint64(p)
+	nLoopAll := nChunk
+	if flags&FlagRW != 0 {
+		if t <= 1 {
+			if t != 0 {
+				nLoopAll *= 2
+			}
+			nLoopAll = (nLoopAll + 2) / 3
+		} else {
+			nLoopAll *= uint64(t - 1)
+		}
+	} else if t != 0 {
+		if t == 1 {
+			nLoopAll += (nLoopAll + 1) / 2
+		}
+		nLoopAll *= uint64(t)
+	}
+
+	var nLoopRW uint64
+	if flags&FlagRW != 0 {
+		nLoopRW = nLoopAll / uint64(p)
+	}
+
+	nChunk &^= 1
+	nLoopAll = (nLoopAll + 1) &^ 1
+	nLoopRW = (nLoopRW + 1) &^ 1
+
+	var ctxs []*pwxformCtx
+	if flags&FlagRW != 0 {
+		ctxs = make([]*pwxformCtx, p)
+	}
+
+	for i := 0; i < p; i++ {
+		vChunk := uint64(i) * nChunk
+		bp := b[128*r*i:]
+		vp := v[int(vChunk)*s:]
+		np := nChunk
+		if i == p-1 {
+			np = N - vChunk
+		}
+
+		var ctx *pwxformCtx
+		if flags&FlagRW != 0 {
+			ctx = &pwxformCtx{s: make([]uint32, sWords)}
+			smix1(bp, 1, sBytes/128, 0, ctx.s, xy, nil)
+			ctx.s2 = ctx.s[:sWords/3]
+			ctx.s1 = ctx.s[sWords/3 : sWords/3*2]
+			ctx.s0 = ctx.s[sWords/3*2:]
+			ctxs[i] = ctx
+
+			if i == 0 {
+				h := hmac.New(sha256.New, bp[128*r-64:128*r])
+				h.Write(sha)
+				copy(sha, h.Sum(nil))
+			}
+		}
+
+		smix1(bp, r, np, flags, vp, xy, ctx)
+		smix2(bp, r, p2floor(np), nLoopRW, flags, vp, xy, ctx)
+	}
+
+	if nLoopAll > nLoopRW {
+		for i := 0; i < p; i++ {
+			var ctx *pwxformCtx
+			if ctxs != nil {
+				ctx = ctxs[i]
+			}
+			smix2(b[128*r*i:], r, N, nLoopAll-nLoopRW, flags&^FlagRW, v, xy, ctx)
+		}
+	}
+
+	for _, ctx := range ctxs {
+		clean32(ctx.s)
+	}
+}
+
+// smix1 fills v with N blocks computed from b, then stores the last one back
+// into b.
+func smix1(b []byte, r int, N uint64, flags uint32, v, xy []uint32, ctx *pwxformCtx) {
+	s := 32 * r
+	x, y := xy[:s], xy[s:2*s]
+
+	load(x, b, r)
+	for i := uint64(0); i < N; i++ {
+		copy(v[int(i)*s:], x)
+		if flags&FlagRW != 0 && i > 1 {
+			j := wrap(integerify(x, r), i)
+			xor(x, v[int(j)*s:int(j+1)*s])
+		}
+		blockMix(x, y, r, ctx)
+	}
+	store(b, x, r)
+}
+
+// smix2 performs Nloop rounds of pseudo-random reads from the N first blocks of
+// v, which are also overwritten in yescrypt's read-write mode.
+func smix2(b []byte, r int, N, nLoop uint64, flags uint32, v, xy []uint32, ctx *pwxformCtx) {
+	if nLoop == 0 {
+		return
+	}
+
+	s := 32 * r
+	x, y := xy[:s], xy[s:2*s]
+
+	load(x, b, r)
+	for i := uint64(0); i < nLoop; i++ {
+		j := integerify(x, r) & (N - 1)
+		vj := v[int(j)*s : int(j+1)*s]
+		xor(x, vj)
+		if flags&FlagRW != 0 {
+			copy(vj, x)
+		}
+		blockMix(x, y, r, ctx)
+	}
+	store(b, x, r)
+}
+
+// load decodes the blocks in b into x, shuffling the words of each 64-byte
+// block in the order the optimized implementations keep them. pwxform depends
+// on this order.
+func load(x []uint32, b []byte, r int) {
+	for k := 0; k < 2*r; k++ {
+		for i := 0; i < 16; i++ {
+			x[k*16+i] = binary.LittleEndian.Uint32(b[(k*16+i*5%16)*4:])
+		}
+	}
+}
+
+// store is the inverse of load.
+func store(b []byte, x []uint32, r int) {
+	for k := 0; k < 2*r; k++ {
+		for i := 0; i < 16; i++ {
+			binary.LittleEndian.PutUint32(b[(k*16+i*5%16)*4:], x[k*16+i])
+		}
+	}
+}
+
+func blockMix(x, y []uint32, r int, ctx *pwxformCtx) {
+	if ctx != nil {
+		blockMixPwxform(x, ctx, r)
+	} else {
+		blockMixSalsa8(x, y, r)
+	}
+}
+
+func blockMixSalsa8(b, y []uint32, r int) {
+	var x [16]uint32
+
+	copy(x[:], b[(2*r-1)*16:])
+	for i := 0; i < 2*r; i++ {
+		xor(x[:], b[i*16:(i+1)*16])
+		salsa20(&x, 8)
+		copy(y[i*16:], x[:])
+	}
+
+	for i := 0; i < r; i++ {
+		copy(b[i*16:(i+1)*16], y[i*2*16:])
+		copy(b[(i+r)*16:(i+r+1)*16], y[(i*2+1)*16:])
+	}
+}
+
+func blockMixPwxform(b []uint32, ctx *pwxformCtx, r int) {
+	var x [pwxWords]uint32
+
+	r1 := 128 * r / pwxBytes
+	copy(x[:], b[(r1-1)*pwxWords:])
+	for i := 0; i < r1; i++ {
+		if r1 > 1 {
+			xor(x[:], b[i*pwxWords:(i+1)*pwxWords])
+		}
+		pwxform(&x, ctx)
+		copy(b[i*pwxWords:], x[:])
+	}
+
+	i := (r1 - 1) * pwxBytes / 64
+	salsa20((*[16]uint32)(b[i*16:(i+1)*16]), 2)
+	for i++; i < 2*r; i++ {
+		xor(b[i*16:(i+1)*16], b[(i-1)*16:i*16])
+		salsa20((*[16]uint32)(b[i*16:(i+1)*16]), 2)
+	}
+}
+
+func pwxform(x *[pwxWords]uint32, ctx *pwxformCtx) {
+	s0, s1, s2, w := ctx.s0, ctx.s1, ctx.s2, ctx.w
+
+	for i := 0; i < pwxRounds; i++ {
+		for j := 0; j < pwxGather; j++ {
+			lane := x[j*pwxSimple*2 : (j+1)*pwxSimple*2]
+			p0 := (lane[0] & sMask) / 4
+			p1 := (lane[1] & sMask) / 4
+			for k := 0; k < pwxSimple; k++ {
+				v := uint64(lane[2*k+1]) * uint64(lane[2*k])
+				v += uint64(s0[p0+uint32(2*k)]) | uint64(s0[p0+uint32(2*k)+1])<<32
+				v ^= uint64(s1[p1+uint32(2*k)]) | uint64(s1[p1+uint32(2*k)+1])<<32
+				lane[2*k] = uint32(v)
+				lane[2*k+1] = uint32(v >> 32)
+				if i != 0 && i != pwxRounds-1 {
+					s2[(w+k)*2] = uint32(v)
+					s2[(w+k)*2+1] = uint32(v >> 32)
+				}
+			}
+			if i != 0 && i != pwxRounds-1 {
+				w += pwxSimple
+			}
+		}
+	}
+
+	ctx.s0, ctx.s1, ctx.s2 = s2, s0, s1
+	ctx.w = w & ((1<<sWidth)*pwxSimple - 1)
+}
+
+// salsa20 applies the Salsa20 core with the given number of rounds to a block
+// whose words are shuffled as load does.
+func salsa20(b *[16]uint32, rounds int) {
+	var x [16]uint32
+	for i := 0; i < 16; i++ {
+		x[i*5%16] = b[i]
+	}
+
+	for i := 0; i < rounds; i += 2 {
+		x[4] ^= bits.RotateLeft32(x[0]+x[12], 7)
+		x[8] ^= bits.RotateLeft32(x[4]+x[0], 9)
+		x[12] ^= bits.RotateLeft32(x[8]+x[4], 13)
+		x[0] ^= bits.RotateLeft32(x[12]+x[8], 18)
+
+		x[9] ^= bits.RotateLeft32(x[5]+x[1], 7)
+		x[13] ^= bits.RotateLeft32(x[9]+x[5], 9)
+		x[1] ^= bits.RotateLeft32(x[13]+x[9], 13)
+		x[5] ^= bits.RotateLeft32(x[1]+x[13], 18)
+
+		x[14] ^= bits.RotateLeft32(x[10]+x[6], 7)
+		x[2] ^= bits.RotateLeft32(x[14]+x[10], 9)
+		x[6] ^= bits.RotateLeft32(x[2]+x[14], 13)
+		x[10] ^= bits.RotateLeft32(x[6]+x[2], 18)
+
+		x[3] ^= bits.RotateLeft32(x[15]+x[11], 7)
+		x[7] ^= bits.RotateLeft32(x[3]+x[15], 9)
+		x[11] ^= bits.RotateLeft32(x[7]+x[3], 13)
+		x[15] ^= bits.RotateLeft32(x[11]+x[7], 18)
+
+		x[1] ^= bits.RotateLeft32(x[0]+x[3], 7)
+		x[2] ^= bits.RotateLeft32(x[1]+x[0], 9)
+		x[3] ^= bits.RotateLeft32(x[2]+x[1], 13)
+		x[0] ^= bits.RotateLeft32(x[3]+x[2], 18)
+
+		x[6] ^= bits.RotateLeft32(x[5]+x[4], 7)
+		x[7] ^= bits.RotateLeft32(x[6]+x[5], 9)
+		x[4] ^= bits.RotateLeft32(x[7]+x[6], 13)
+		x[5] ^= bits.RotateLeft32(x[4]+x[7], 18)
+
+		x[11] ^= bits.RotateLeft32(x[10]+x[9], 7)
+		x[8] ^= bits.RotateLeft32(x[11]+x[10], 9)
+		x[9] ^= bits.RotateLeft32(x[8]+x[11], 13)
+		x[10] ^= bits.RotateLeft32(x[9]+x[8], 18)
+
+		x[12] ^= bits.RotateLeft32(x[15]+x[14], 7)
+		x[13] ^= bits.RotateLeft32(x[12]+x[15], 9)
+		x[14] ^= bits.RotateLeft32(x[13]+x[12], 13)
+		x[15] ^= bits.RotateLeft32(x[14]+x[13], 18)
+	}
+
+	for i := 0; i < 16; i++ {
+		b[i] += x[i*5%16]
+	}
+}
+
+func integerify(x []uint32, r int) uint64 {
+	// The second word of the last block is found at index 13 once shuffled.
+	last := x[(2*r-1)*16:]
+	return uint64(last[13])<<32 | uint64(last[0])
+}
+
+func p2floor(x uint64) uint64 {
+	for x&(x-1) != 0 {
+		x &= x - 1
+	}
+	return x
+}
+
+func wrap(x, i uint64) uint64 {
+	n := p2floor(i)
+	return x&(n-1) + (i - n)
+}
+
+func xor(dst, src []uint32) {
+	for i := range dst {
+		dst[i] ^= src[i]
+	}
+}
+
+func clean(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+func clean32(b []uint32) {
+	for i := range b {
+		b[i] = 0
+	}
+}
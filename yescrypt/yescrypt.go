@@ -0,0 +1,352 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+// Package yescrypt implements Solar Designer's yescrypt password hashing
+// algorithm, in the "$y$" format produced by libxcrypt.
+//
+// The classic scrypt, scrypt with yescrypt's WORM extensions and yescrypt's
+// native read-write mode are supported, with any number of threads and any
+// time cost. ROM and hash upgrades are not supported, nor are parameters which
+// need more than 1 GiB of memory.
+//
+// The specification for this algorithm can be found here:
+// https://www.openwall.com/yescrypt/
+package yescrypt
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"math/bits"
+	"strings"
+
+	"github.com/GehirnInc/crypt"
+	"github.com/GehirnInc/crypt/common"
+)
+
+func init() {
+	crypt.RegisterCrypt(crypt.YESCRYPT, New, MagicPrefix)
+}
+
+const (
+	MagicPrefix = "$y$"
+	SaltLenMin  = 2  // a single byte
+	SaltLenMax  = 86 // 64 bytes
+	SaltLenGen  = 22 // 16 bytes, as libxcrypt generates
+	HashLen     = 32
+)
+
+// Flags selecting the yescrypt mode. FlagsDefault, the read-write mode with the
+// only pwxform settings in use, is what libxcrypt generates.
+const (
+	FlagWORM     = 0x001
+	FlagRW       = 0x002
+	FlagsDefault = FlagRW | 0x004 | 0x010 | 0x020 | 0x080
+
+	flagModeMask    = 0x003
+	flagsFlavorMask = 0x3fc
+)
+
+// DefaultParams are the parameters used by libxcrypt for its default cost of 5:
+// 4 MiB of memory and a single thread.
+var DefaultParams = Params{Flags: FlagsDefault, N: 4096, R: 32, P: 1}
+
+var (
+	ErrParamsFormat  = errors.New("yescrypt: invalid parameters encoding")
+	ErrParamsUnknown = errors.New("yescrypt: unsupported parameters")
+)
+
+// Params are the yescrypt parameters encoded in a hashed key.
+type Params struct {
+	Flags uint32 // mode and pwxform flavor; zero means classic scrypt
+	N     uint64 // block count, a power of two
+	R     uint32 // block size, in units of 128 bytes
+	P     uint32 // parallelism
+	T     uint32 // additional time cost
+}
+
+// Encode returns the parameters in the variable-length encoding used by
+// yescrypt, without the magic prefix.
+func (p Params) Encode() ([]byte, error) {
+	var flavor uint32
+	if p.Flags < FlagRW {
+		flavor = p.Flags
+	} else if p.Flags&flagModeMask == FlagRW && p.Flags <= FlagRW|flagsFlavorMask {
+		flavor = FlagRW + p.Flags>>2
+	} else {
+		return nil, ErrParamsUnknown
+	}
+	if p.N <= 1 || p.N&(p.N-1) != 0 || p.R < 1 || p.P < 1 {
+		return nil, ErrParamsUnknown
+	}
+
+	var have uint32
+	if p.P != 1 {
+		have |= 1
+	}
+	if p.T != 0 {
+		have |= 2
+	}
+
+	dst := make([]byte, 0, 16)
+	dst = encodeUint32(dst, flavor, 0)
+	dst = encodeUint32(dst, uint32(bits.TrailingZeros64(p.N)), 1)
+	dst = encodeUint32(dst, p.R, 1)
+	if have != 0 {
+		dst = encodeUint32(dst, have, 1)
+	}
+	if p.P != 1 {
+		dst = encodeUint32(dst, p.P, 2)
+	}
+	if p.T != 0 {
+		dst = encodeUint32(dst, p.T, 1)
+	}
+	return dst, nil
+}
+
+// DecodeParams decodes the parameters at the start of src, which is a setting
+// or a hashed key without its magic prefix. It returns the rest of src.
+func DecodeParams(src []byte) (p Params, rest []byte, err error) {
+	var flavor, nLog2 uint32
+	if flavor, src, err = decodeUint32(src, 0); err != nil {
+		return
+	}
+	if flavor < FlagRW {
+		p.Flags = flavor
+	} else if flavor <= FlagRW+flagsFlavorMask>>2 {
+		p.Flags = FlagRW + (flavor-FlagRW)<<2
+	} else {
+		err = ErrParamsUnknown
+		return
+	}
+
+	if nLog2, src, err = decodeUint32(src, 1); err != nil {
+		return
+	}
+	if nLog2 > 63 {
+		err = ErrParamsFormat
+		return
+	}
+	p.N = 1 << nLog2
+
+	if p.R, src, err = decodeUint32(src, 1); err != nil {
+		return
+	}
+
+	p.P = 1
+	if len(src) > 0 && src[0] != '$' {
+		var have uint32
+		if have, src, err = decodeUint32(src, 1); err != nil {
+			return
+		}
+		if have&^3 != 0 {
+			// Hash upgrades and ROM.
+			err = ErrParamsUnknown
+			return
+		}
+		if have&1 != 0 {
+			if p.P, src, err = decodeUint32(src, 2); err != nil {
+				return
+			}
+		}
+		if have&2 != 0 {
+			if p.T, src, err = decodeUint32(src, 1); err != nil {
+				return
+			}
+		}
+	}
+
+	rest = src
+	return
+}
+
+// Cost returns the base-2 logarithm of N.
+func (p Params) Cost() int { return bits.TrailingZeros64(p.N) }
+
+// GenerateSalt returns a setting made of the magic prefix, the given
+// parameters and a random salt of SaltLenGen characters.
+func GenerateSalt(params Params) ([]byte, error) {
+	return generateSalt([]byte(MagicPrefix), params)
+}
+
+func generateSalt(magicPrefix []byte, params Params) ([]byte, error) {
+	encoded, err := params.Encode()
+	if err != nil {
+		return nil, err
+	}
+
+	salt := make([]byte, SaltLenGen*6/8)
+	rand.Read(salt)
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(magicPrefix) + len(encoded) + 1 + SaltLenGen)
+	buf.Write(magicPrefix)
+	buf.Write(encoded)
+	buf.WriteByte('$')
+	buf.Write(common.Base64_24Bit(salt))
+	return buf.Bytes(), nil
+}
+
+type crypter struct{ Salt common.Salt }
+
+// New returns a new crypt.Crypter computing the yescrypt password hashing.
+func New() crypt.Crypter {
+	return &crypter{
+		common.Salt{
+			MagicPrefix: []byte(MagicPrefix),
+			SaltLenMin:  SaltLenMin,
+			SaltLenMax:  SaltLenMax,
+		},
+	}
+}
+
+func (c *crypter) Generate(key, salt []byte) (string, error) {
+	if len(salt) == 0 {
+		var err error
+		if salt, err = generateSalt(c.Salt.MagicPrefix, DefaultParams); err != nil {
+			return "", err
+		}
+	}
+	params, setting, encodedSalt, err := c.decode(salt)
+	if err != nil {
+		return "", err
+	}
+	rawSalt, err := common.DecodeBase64_24Bit(encodedSalt)
+	if err != nil {
+		return "", common.ErrSaltFormat
+	}
+
+	sum, err := Key(key, rawSalt, params, HashLen)
+	if err != nil {
+		return "", err
+	}
+
+	buf := bytes.Buffer{}
+	buf.Grow(len(setting) + 1 + 43)
+	buf.Write(setting)
+	buf.WriteByte('$')
+	buf.Write(common.Base64_24Bit(sum))
+	return buf.String(), nil
+}
+
+func (c *crypter) Verify(hashedKey string, key []byte) error {
+	newHash, err := c.Generate(key, []byte(hashedKey))
+	if err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(newHash), []byte(hashedKey)) != 1 {
+		return crypt.ErrKeyMismatch
+	}
+	return nil
+}
+
+// Cost returns the base-2 logarithm of N, the block count.
+func (c *crypter) Cost(hashedKey string) (int, error) {
+	params, _, _, err := c.decode([]byte(hashedKey))
+	if err != nil {
+		return 0, err
+	}
+	return params.Cost(), nil
+}
+
+func (c *crypter) SetSalt(salt common.Salt) { c.Salt = salt }
+
+// decode splits raw into its parameters, the setting part which is reproduced
+// in the output, and the encoded salt.
+func (c *crypter) decode(raw []byte) (params Params, setting, salt []byte, err error) {
+	if !bytes.HasPrefix(raw, c.Salt.MagicPrefix) {
+		err = common.ErrSaltPrefix
+		return
+	}
+
+	params, rest, err := DecodeParams(raw[len(c.Salt.MagicPrefix):])
+	if err != nil {
+		return
+	}
+	if len(rest) == 0 || rest[0] != '$' {
+		err = ErrParamsFormat
+		return
+	}
+	rest = rest[1:]
+	saltStart := len(raw) - len(rest)
+
+	if i := bytes.LastIndexByte(rest, '$'); i >= 0 {
+		rest = rest[:i]
+	}
+	if len(rest) < c.Salt.SaltLenMin || len(rest) > c.Salt.SaltLenMax {
+		err = common.ErrSaltFormat
+		return
+	}
+
+	salt = rest
+	setting = raw[:saltStart+len(salt)]
+	return
+}
+
+const itoa64 = "./0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// encodeUint32 appends src, which must be at least min, in yescrypt's
+// variable-length encoding of integers.
+func encodeUint32(dst []byte, src, min uint32) []byte {
+	var start, end, chars, bits uint32 = 0, 47, 1, 0
+
+	src -= min
+	for {
+		count := (end + 1 - start) << bits
+		if src < count {
+			break
+		}
+		start = end + 1
+		end = start + (62-end)/2
+		src -= count
+		chars++
+		bits += 6
+	}
+
+	dst = append(dst, itoa64[start+src>>bits])
+	for chars--; chars > 0; chars-- {
+		bits -= 6
+		dst = append(dst, itoa64[src>>bits&0x3f])
+	}
+	return dst
+}
+
+// decodeUint32 is the inverse of encodeUint32.
+func decodeUint32(src []byte, min uint32) (dst uint32, rest []byte, err error) {
+	var start, end, chars, bits uint32 = 0, 47, 1, 0
+
+	if len(src) == 0 {
+		return 0, nil, ErrParamsFormat
+	}
+	c := strings.IndexByte(itoa64, src[0])
+	if c < 0 {
+		return 0, nil, ErrParamsFormat
+	}
+	src = src[1:]
+
+	dst = min
+	for uint32(c) > end {
+		dst += (end + 1 - start) << bits
+		start = end + 1
+		end = start + (62-end)/2
+		chars++
+		bits += 6
+	}
+	dst += (uint32(c) - start) << bits
+
+	for chars--; chars > 0; chars-- {
+		if len(src) == 0 {
+			return 0, nil, ErrParamsFormat
+		}
+		c = strings.IndexByte(itoa64, src[0])
+		if c < 0 {
+			return 0, nil, ErrParamsFormat
+		}
+		src = src[1:]
+		bits -= 6
+		dst += uint32(c) << bits
+	}
+	return dst, src, nil
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2026 The crypt Authors. All rights reserved.
+// This software is licensed under the 3-Clause BSD License
+// that can be found in LICENSE file.
+
+package yescrypt
+
+import (
+	"testing"
+
+	"github.com/GehirnInc/crypt"
+)
+
+var yescryptCrypt = New()
+
+func TestGenerate(t *testing.T) {
+	data := []struct {
+		salt []byte
+		key  []byte
+		out  string
+		cost int
+	}{
+		{
+			[]byte("$y$j9T$salt"),
+			[]byte("password"),
+			"$y$j9T$salt$Cw3H19laQT.rHIYMLvuoUzLb8st7PboO9rfxAylYPx9",
+			12,
+		},
+		{
+			[]byte("$y$j9T$LdJMENpBABJJ3hIHjB1Bi."),
+			[]byte("Hello world!"),
+			"$y$j9T$LdJMENpBABJJ3hIHjB1Bi.$zW5G4BIAAeOQpBvY3MQbaO1vXUDwmoOegrzuQYvjwo/",
+			12,
+		},
+		{
+			[]byte("$y$jC5$LdJMENpBABJJ3hIHjB1Bi.$dbwxQiuKf7pf2PtDqFYriW4GNGm4qxBEz2718dvotm8"),
+			[]byte("Hello world!"),
+			"$y$jC5$LdJMENpBABJJ3hIHjB1Bi.$dbwxQiuKf7pf2PtDqFYriW4GNGm4qxBEz2718dvotm8",
+			15,
+		},
+		{
+			[]byte("$y$j75$JF7Zwzl/"),
+			[]byte(""),
+			"$y$j75$JF7Zwzl/$Dt9TydX94HutUG2Vu8oget64ouXk7Ygh4uSUuYKEIJ6",
+			10,
+		},
+		{
+			// p = 2
+			[]byte("$y$j85..$saltSALT"),
+			[]byte("Hello world!"),
+			"$y$j85..$saltSALT$sVkZK1X5xvvqPtlv5h.2RHc8L3SXkYdwtur5mV49TI0",
+			11,
+		},
+		{
+			// p = 3, t = 2
+			[]byte("$y$j750//$saltSALT"),
+			[]byte("pass"),
+			"$y$j750//$saltSALT$un385vEQ.yUjBKJEHCGBHaKbHjUsQ8GYaE/49xukav0",
+			10,
+		},
+		{
+			// classic scrypt
+			[]byte("$y$.75$saltSALT"),
+			[]byte("pass"),
+			"$y$.75$saltSALT$lsEjMblqUY4qFb7Vk4zuWRnduVzO9lgIZTA6wyAydBC",
+			10,
+		},
+		{
+			// WORM, p = 2, t = 1
+			[]byte("$y$/750..$saltSALT"),
+			[]byte("pass"),
+			"$y$/750..$saltSALT$K8ZbnjOx1dGmQW.4SorSpQx.QuBlO7ta/aKltVTttJ6",
+			10,
+		},
+	}
+
+	for i, d := range data {
+		hash, err := yescryptCrypt.Generate(d.key, d.salt)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hash != d.out {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.out, hash)
+		}
+
+		cost, err := yescryptCrypt.Cost(hash)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cost != d.cost {
+			t.Errorf("Test %d failed\nExpected: %d, got: %d", i, d.cost, cost)
+		}
+	}
+}
+
+func TestGenerateInvalidSalt(t *testing.T) {
+	data := []string{
+		"$7$CU..../....salt",
+		"$y$j9T",
+		"$y$j9Tsalt",
+		"$y$j9T$",
+		"$y$j9T$/",
+		"$y$j9T$ab",
+		"$y$j9T$sa*t",
+		"$y$z9T$salt",
+	}
+	for i, d := range data {
+		if _, err := yescryptCrypt.Generate([]byte("password"), []byte(d)); err == nil {
+			t.Errorf("Test %d failed: %s was accepted", i, d)
+		}
+	}
+}
+
+func TestMemoryMax(t *testing.T) {
+	salt, err := GenerateSalt(Params{Flags: FlagsDefault, N: 1 << 24, R: 64, P: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = yescryptCrypt.Generate([]byte("password"), salt); err != errParamsLarge {
+		t.Errorf("Parameters above 1 GiB were accepted: %s", salt)
+	}
+}
+
+func TestParams(t *testing.T) {
+	data := []struct {
+		params  Params
+		encoded string
+	}{
+		{DefaultParams, "j9T"},
+		{Params{Flags: FlagsDefault, N: 2048, R: 8, P: 2}, "j85.."},
+		{Params{Flags: FlagsDefault, N: 1024, R: 8, P: 3, T: 2}, "j750//"},
+		{Params{Flags: FlagsDefault, N: 1 << 16, R: 100}, "jDkn"},
+		{Params{Flags: 0, N: 1024, R: 8}, ".75"},
+		{Params{Flags: FlagWORM, N: 1024, R: 8, P: 2, T: 1}, "/750.."},
+	}
+
+	for i, d := range data {
+		if d.params.P == 0 {
+			d.params.P = 1
+		}
+
+		encoded, err := d.params.Encode()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(encoded) != d.encoded {
+			t.Errorf("Test %d failed\nExpected: %s, got: %s", i, d.encoded, encoded)
+		}
+
+		params, rest, err := DecodeParams([]byte(d.encoded + "$salt"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if params != d.params {
+			t.Errorf("Test %d failed\nExpected: %+v, got: %+v", i, d.params, params)
+		}
+		if string(rest) != "$salt" {
+			t.Errorf("Test %d failed: unexpected rest %s", i, rest)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	data := [][]byte{
+		[]byte("password"),
+		[]byte("12345"),
+		[]byte("That's amazing! I've got the same combination on my luggage!"),
+		[]byte("And change the combination on my luggage!"),
+		[]byte("         random  spa  c    ing."),
+		[]byte("94ajflkvjzpe8u3&*j1k513KLJ&*()"),
+	}
+	for i, d := range data {
+		hash, err := yescryptCrypt.Generate(d, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = yescryptCrypt.Verify(hash, d); err != nil {
+			t.Errorf("Test %d failed: %s", i, d)
+		}
+		if err = yescryptCrypt.Verify(hash, []byte("wrong")); err != crypt.ErrKeyMismatch {
+			t.Errorf("Test %d failed: wrong key was accepted", i)
+		}
+	}
+}